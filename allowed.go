@@ -0,0 +1,49 @@
+package trial
+
+import (
+	"regexp"
+	"strings"
+)
+
+// AllowedDiffs lets a case pass when all of its differences match one of the
+// regular expression patterns. Each changed line (starting with - or +)
+// must match a pattern, when there are no changed lines the whole difference
+// must match. Cases that pass because of an allowed difference report it.
+func (t *Trial) AllowedDiffs(patterns ...string) *Trial {
+	for _, p := range patterns {
+		t.allowed = append(t.allowed, regexp.MustCompile(p))
+	}
+	return t
+}
+
+// isAllowedDiff checks if all changes in the diff match an allowed pattern
+func (t *Trial) isAllowedDiff(diff string) bool {
+	if len(t.allowed) == 0 {
+		return false
+	}
+	changes := 0
+	for _, ln := range strings.Split(diff, "\n") {
+		// cmp randomly uses non-breaking spaces in its output
+		s := strings.TrimLeft(strings.Replace(ln, "\u00a0", " ", -1), " \t")
+		if !strings.HasPrefix(s, "-") && !strings.HasPrefix(s, "+") {
+			continue
+		}
+		changes++
+		if !t.matchAllowed(s) {
+			return false
+		}
+	}
+	if changes == 0 {
+		return t.matchAllowed(diff)
+	}
+	return true
+}
+
+func (t *Trial) matchAllowed(s string) bool {
+	for _, re := range t.allowed {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
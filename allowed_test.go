@@ -0,0 +1,53 @@
+package trial
+
+import (
+	"testing"
+)
+
+func TestTrial_AllowedDiffs(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	cases := map[string]struct {
+		trial   *Trial
+		Case    Case
+		success bool
+	}{
+		"no allowed diffs": {
+			trial:   New(fn, nil),
+			Case:    Case{Input: 1.0000001, Expected: 1.0},
+			success: false,
+		},
+		"allowed float rounding": {
+			trial:   New(fn, nil).AllowedDiffs(`^[-+]\s*1(\.0+1)?,?$`),
+			Case:    Case{Input: 1.0000001, Expected: 1.0},
+			success: true,
+		},
+		"unallowed difference in struct": {
+			trial:   New(fn, nil).AllowedDiffs(`X:`),
+			Case:    Case{Input: point{X: 1, Y: 2}, Expected: point{X: 2, Y: 3}},
+			success: false,
+		},
+		"all struct differences allowed": {
+			trial:   New(fn, nil).AllowedDiffs(`X:`, `Y:`),
+			Case:    Case{Input: point{X: 1, Y: 2}, Expected: point{X: 2, Y: 3}},
+			success: true,
+		},
+		"custom comparer message": {
+			trial:   New(fn, nil).Comparer(EqualTime(0)).AllowedDiffs(`delta 1h0m0s`),
+			Case:    Case{Input: TimeHour("2020-01-01T01"), Expected: TimeHour("2020-01-01T00")},
+			success: true,
+		},
+	}
+	for msg, test := range cases {
+		r := test.trial.testCase(msg, test.Case)
+		if r.Success != test.success {
+			t.Errorf("FAIL: %q %s", msg, r.Message)
+		}
+	}
+
+	r := New(fn, nil).AllowedDiffs(`Y:`).testCase("report", Case{Input: point{Y: 1}, Expected: point{Y: 2}})
+	if eq, _ := Contains(r.Message, `PASS: "report" with allowed differences`); !eq {
+		t.Errorf("FAIL: allowed diff not reported %q", r.Message)
+	}
+}
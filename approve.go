@@ -0,0 +1,65 @@
+package trial
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// Approve compares each case's result against a previously approved result
+// stored in dir/<name>.approved instead of the Expected value.
+// The actual result is written to dir/<name>.received, on failure the file is left
+// for review and can be renamed to .approved to accept the new result.
+// A case without an approved file always fails.
+func (t *Trial) Approve(dir string) *Trial {
+	t.approveDir = dir
+	return t
+}
+
+// approve the result of a case against the approved file
+func (t *Trial) approve(name string, result interface{}) (bool, string) {
+	base := filepath.Join(t.approveDir, unsafeFileChars.ReplaceAllString(name, "_"))
+	received, approved := base+".received", base+".approved"
+
+	actual, err := approvalText(result)
+	if err != nil {
+		return false, fmt.Sprintf("could not format result: %v", err)
+	}
+	if err := os.MkdirAll(t.approveDir, 0755); err != nil {
+		return false, err.Error()
+	}
+	if err := ioutil.WriteFile(received, []byte(actual), 0644); err != nil {
+		return false, err.Error()
+	}
+
+	b, err := ioutil.ReadFile(approved)
+	if os.IsNotExist(err) {
+		return false, fmt.Sprintf("no approved result, review %s and rename to %s", received, approved)
+	} else if err != nil {
+		return false, err.Error()
+	}
+	if equal, diff := Equal(actual, string(b)); !equal {
+		return false, fmt.Sprintf("%s does not match %s\n%s", received, approved, diff)
+	}
+	os.Remove(received)
+	return true, ""
+}
+
+// approvalText converts the result to the text stored in an approval file
+func approvalText(result interface{}) (string, error) {
+	switch v := result.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	b, err := json.MarshalIndent(result, "", "  ")
+	return string(b), err
+}
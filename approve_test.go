@@ -0,0 +1,56 @@
+package trial
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrial_Approve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trial")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	tr := New(fn, nil).Approve(dir)
+
+	// first run has nothing approved
+	r := tr.testCase("first run", Case{Input: "hello"})
+	if r.Success {
+		t.Errorf("FAIL: unapproved case should fail %q", r.Message)
+	}
+	received := filepath.Join(dir, "first_run.received")
+	if b, _ := ioutil.ReadFile(received); string(b) != "hello" {
+		t.Errorf("FAIL: received file %q", string(b))
+	}
+
+	// promote the result and verify it now passes
+	if err := os.Rename(received, filepath.Join(dir, "first_run.approved")); err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.testCase("first run", Case{Input: "hello"}); !r.Success {
+		t.Errorf("FAIL: approved case %q", r.Message)
+	}
+	if _, err := os.Stat(received); !os.IsNotExist(err) {
+		t.Error("FAIL: received file should be removed on success")
+	}
+
+	// a changed result fails and leaves the received file
+	if r := tr.testCase("first run", Case{Input: "world"}); r.Success {
+		t.Error("FAIL: changed result should fail")
+	}
+	if _, err := os.Stat(received); err != nil {
+		t.Errorf("FAIL: received file should remain %v", err)
+	}
+
+	// structs are stored as json
+	r = tr.testCase("struct", Case{Input: point{X: 1, Y: 2}})
+	if b, _ := ioutil.ReadFile(filepath.Join(dir, "struct.received")); string(b) != "{\n  \"X\": 1,\n  \"Y\": 2\n}" {
+		t.Errorf("FAIL: struct received file %q", string(b))
+	}
+}
@@ -0,0 +1,45 @@
+package trial
+
+import (
+	"testing"
+)
+
+// Benchmark runs the TestFunc of each case b.N times as a sub-benchmark
+// named after the case. Inputs are passed the same way as Test and the
+// results, errors and panics are ignored. Skipped cases are not benchmarked.
+// Setup and Teardown hooks are run around each sub-benchmark outside of the timer
+func (t *Trial) Benchmark(b *testing.B) {
+	if !t.ready(b) {
+		return
+	}
+	defer t.fixture.teardown()
+	names, _ := t.focus(t.caseNames())
+	for _, msg := range names {
+		test := t.cases[msg]
+		if test.Skip || test.SkipReason != "" || test.platformSkip() != "" {
+			continue
+		}
+		b.Run(msg, func(b *testing.B) {
+			defer t.teardown()
+			if err := t.setup(); err != nil {
+				b.Fatalf("FAIL: %q setup failed: %v", msg, err)
+			}
+			fn, done := t.caseFunc(test)
+			defer done()
+			if t.cleanupFn != nil {
+				// release the resources of each iteration instead of holding b.N of them
+				fn = t.testFn
+			}
+			args := t.args(test.Input)
+			defer func() {
+				if rec := recover(); rec != nil {
+					b.Logf("stopped after panic: %v", rec)
+				}
+			}()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fn(args...)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package trial
+
+import (
+	"errors"
+	"flag"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTrial_Benchmark(t *testing.T) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	fn := func(args ...interface{}) (interface{}, error) {
+		s := args[0].(string)
+		if s == "panic" {
+			panic("benchmark panic")
+		}
+		mu.Lock()
+		calls[s+strings.Repeat("!", len(args)-1)]++
+		mu.Unlock()
+		return strings.ToUpper(s), nil
+	}
+	// keep the benchmark short
+	if f := flag.Lookup("test.benchtime"); f != nil {
+		orig := f.Value.String()
+		f.Value.Set("10x")
+		defer f.Value.Set(orig)
+	}
+	testing.Benchmark(New(fn, Cases{
+		"single":  {Input: "a"},
+		"args":    {Input: Args("b", 1)},
+		"panic":   {Input: "panic", ShouldPanic: true},
+		"skipped": {Input: "c", Skip: true},
+	}).Benchmark)
+	if calls["a"] == 0 || calls["b!"] == 0 {
+		t.Errorf("FAIL: cases were not benchmarked %v", calls)
+	}
+	if calls["c"] != 0 {
+		t.Error("FAIL: skipped case was benchmarked")
+	}
+}
+
+func TestTrial_BenchmarkCleanup(t *testing.T) {
+	var open, maxOpen int
+	fn := func(args ...interface{}) (interface{}, func(), error) {
+		if open++; open > maxOpen {
+			maxOpen = open
+		}
+		return nil, func() { open-- }, nil
+	}
+	if f := flag.Lookup("test.benchtime"); f != nil {
+		orig := f.Value.String()
+		f.Value.Set("10x")
+		defer f.Value.Set(orig)
+	}
+	testing.Benchmark(NewWithCleanup(fn, Cases{"open": {}}).Benchmark)
+	if open != 0 || maxOpen != 1 {
+		t.Errorf("FAIL: cleanups should run after each iteration, %d open, max %d", open, maxOpen)
+	}
+}
+
+func TestTrial_BenchmarkHooks(t *testing.T) {
+	var setups, teardowns, calls int
+	fn := func(args ...interface{}) (interface{}, error) {
+		if setups == teardowns {
+			t.Error("FAIL: called without setup")
+		}
+		calls++
+		return nil, nil
+	}
+	if f := flag.Lookup("test.benchtime"); f != nil {
+		orig := f.Value.String()
+		f.Value.Set("10x")
+		defer f.Value.Set(orig)
+	}
+	testing.Benchmark(New(fn, Cases{"a": {}, "b": {}}).
+		Setup(func() error { setups++; return nil }).
+		Teardown(func() { teardowns++ }).
+		Benchmark)
+	if calls == 0 || setups < 2 || setups != teardowns {
+		t.Errorf("FAIL: hooks not run around each case, %d setups %d teardowns", setups, teardowns)
+	}
+
+	calls = 0
+	testing.Benchmark(New(fn, Cases{"a": {}}).Setup(func() error { return errors.New("no db") }).Benchmark)
+	if calls != 0 {
+		t.Error("FAIL: benchmark should stop when setup fails")
+	}
+}
+
+func BenchmarkTrial(b *testing.B) {
+	New(func(args ...interface{}) (interface{}, error) {
+		return strings.Repeat(args[0].(string), args[1].(int)), nil
+	}, Cases{
+		"short": {Input: Args("a", 10)},
+		"long":  {Input: Args("a", 1000)},
+	}).Benchmark(b)
+}
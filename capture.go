@@ -103,3 +103,15 @@ func (c *capture) reset() {
 		c.stdout = nil
 	}
 }
+
+// CaptureFunc creates a TestFunc that returns the output fn writes to stdout
+// as the result (see ReadAll). The error from fn is returned unchanged and
+// stdout is restored even if fn panics.
+func CaptureFunc(fn TestFunc) TestFunc {
+	return func(args ...interface{}) (interface{}, error) {
+		c := CaptureStdOut()
+		defer c.reset()
+		_, err := fn(args...)
+		return c.ReadAll(), err
+	}
+}
@@ -1,6 +1,7 @@
 package trial
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -78,3 +79,36 @@ func Test_CaptureStdOut(t *testing.T) {
 		t.Errorf("FAIL: multi-line %s", diff)
 	}
 }
+
+func TestCaptureFunc(t *testing.T) {
+	greet := func(args ...interface{}) (interface{}, error) {
+		name := args[0].(string)
+		if name == "" {
+			return nil, errors.New("no name")
+		}
+		if name == "panic" {
+			fmt.Println("before panic")
+			panic("greet")
+		}
+		fmt.Printf("hello %s\nbye %s\n", name, name)
+		return nil, nil
+	}
+	stdout := os.Stdout
+	New(CaptureFunc(greet), Cases{
+		"stdout is the result": {
+			Input:    "bob",
+			Expected: "hello bob\nbye bob",
+		},
+		"error is returned": {
+			Input:       "",
+			ExpectedErr: errors.New("no name"),
+		},
+		"panic": {
+			Input:       "panic",
+			ShouldPanic: true,
+		},
+	}).Test(t)
+	if os.Stdout != stdout {
+		t.Error("FAIL: stdout not restored")
+	}
+}
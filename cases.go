@@ -0,0 +1,25 @@
+package trial
+
+import (
+	"fmt"
+)
+
+// Add the case c named name and return the cases for chaining.
+// Add panics if a case with the same name was already added
+func (c Cases) Add(name string, test Case) Cases {
+	if _, found := c[name]; found {
+		panic(fmt.Sprintf("trial: duplicate case %q", name))
+	}
+	c[name] = test
+	return c
+}
+
+// Generate creates n cases by calling fn with each index from 0 to n-1.
+// fn returns the name and Case, names must be unique
+func Generate(n int, fn func(i int) (string, Case)) Cases {
+	cases := make(Cases, n)
+	for i := 0; i < n; i++ {
+		cases.Add(fn(i))
+	}
+	return cases
+}
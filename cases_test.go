@@ -0,0 +1,36 @@
+package trial
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCases_Add(t *testing.T) {
+	cases := Cases{}.
+		Add("a", Case{Input: 1}).
+		Add("b", Case{Input: 2})
+	if eq, diff := Equal(cases, Cases{"a": {Input: 1}, "b": {Input: 2}}); !eq {
+		t.Error("FAIL:", diff)
+	}
+
+	New(func(args ...interface{}) (interface{}, error) {
+		return Cases{"a": {}}.Add(args[0].(string), Case{}), nil
+	}, Cases{
+		"new name":       {Input: "b", Expected: Cases{"a": {}, "b": {}}},
+		"duplicate name": {Input: "a", ExpectedPanic: `trial: duplicate case "a"`},
+	}).Test(t)
+}
+
+func TestGenerate(t *testing.T) {
+	square := func(args ...interface{}) (interface{}, error) {
+		i := args[0].(int)
+		return i * i, nil
+	}
+	cases := Generate(10, func(i int) (string, Case) {
+		return fmt.Sprintf("%d squared", i), Case{Input: i, Expected: i * i}
+	})
+	if len(cases) != 10 {
+		t.Fatalf("FAIL: %d cases generated, expected 10", len(cases))
+	}
+	New(square, cases).Test(t)
+}
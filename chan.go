@@ -0,0 +1,89 @@
+package trial
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// EqualChanOrdered compares the values received from a channel (actual)
+// with an expected slice. Values must arrive in the same order as expected
+// and within the timeout.
+func EqualChanOrdered(timeout time.Duration) CompareFunc {
+	return func(actual, expected interface{}) (bool, string) {
+		ch := reflect.ValueOf(actual)
+		if ch.Kind() != reflect.Chan {
+			return false, fmt.Sprintf("type mismatch %T is not a channel", actual)
+		}
+		exp := reflect.ValueOf(expected)
+		if !isList(exp) {
+			return false, fmt.Sprintf("type mismatch %T is not a slice", expected)
+		}
+		want := listValues(exp)
+		got, closed := drain(ch, len(want), timeout)
+		for i, v := range got {
+			if eq, diff := Equal(v, want[i]); !eq {
+				return false, fmt.Sprintf("order differs at index %d\n%s", i, diff)
+			}
+		}
+		if len(got) == len(want) {
+			return true, ""
+		}
+		d := NewDiff()
+		if closed {
+			d.Errorf("channel closed after %d of %d values", len(got), len(want))
+		} else {
+			d.Errorf("received %d of %d values within %v", len(got), len(want), timeout)
+		}
+		return false, d.Missing(want[len(got):]...).String()
+	}
+}
+
+// DefaultChanTimeout is how long EqualChan waits for a channel to close when no timeout is given
+var DefaultChanTimeout = time.Second
+
+// EqualChan drains a channel result until it's closed and compares the
+// received values with the expected slice using Equal. The timeout (or
+// DefaultChanTimeout when 0) stops waiting on a channel that is never closed
+// and the values received so far are shown.
+func EqualChan(timeout time.Duration) CompareFunc {
+	if timeout <= 0 {
+		timeout = DefaultChanTimeout
+	}
+	return func(actual, expected interface{}) (bool, string) {
+		ch := reflect.ValueOf(actual)
+		if ch.Kind() != reflect.Chan {
+			return Equal(actual, expected)
+		}
+		values, closed := drain(ch, -1, timeout)
+		got := reflect.MakeSlice(reflect.SliceOf(ch.Type().Elem()), 0, len(values))
+		for _, v := range values {
+			got = reflect.Append(got, reflect.ValueOf(v))
+		}
+		if !closed {
+			return false, fmt.Sprintf("channel not closed within %v, received %v", timeout, got)
+		}
+		return Equal(got.Interface(), expected)
+	}
+}
+
+// drain reads up to max values (unlimited when max < 0) from a channel
+// until it is closed or the timeout is reached.
+func drain(ch reflect.Value, max int, timeout time.Duration) (values []interface{}, closed bool) {
+	values = make([]interface{}, 0)
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(time.After(timeout))},
+	}
+	for max < 0 || len(values) < max {
+		chosen, v, ok := reflect.Select(cases)
+		if chosen == 1 {
+			return values, false
+		}
+		if !ok {
+			return values, true
+		}
+		values = append(values, v.Interface())
+	}
+	return values, false
+}
@@ -0,0 +1,110 @@
+package trial
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEqualChanOrdered(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		b, s := EqualChanOrdered(10*time.Millisecond)(args[0], args[1])
+		var err error
+		if s != "" {
+			err = errors.New(s)
+		}
+		return b, err
+	}
+	produce := func(closed bool, values ...int) chan int {
+		ch := make(chan int, len(values))
+		for _, v := range values {
+			ch <- v
+		}
+		if closed {
+			close(ch)
+		}
+		return ch
+	}
+	New(fn, Cases{
+		"values in order": {
+			Input:    Args(produce(false, 1, 2, 3), []int{1, 2, 3}),
+			Expected: true,
+		},
+		"only drain expected values": {
+			Input:    Args(produce(false, 1, 2, 3), []int{1, 2}),
+			Expected: true,
+		},
+		"order differs": {
+			Input:       Args(produce(true, 1, 3, 2), []int{1, 2, 3}),
+			ExpectedErr: errors.New("order differs at index 1"),
+		},
+		"timeout": {
+			Input:       Args(produce(false, 1), []int{1, 2}),
+			ExpectedErr: errors.New("received 1 of 2 values within 10ms\n - 2"),
+		},
+		"closed channel": {
+			Input:       Args(produce(true, 1), []int{1, 2, 3}),
+			ExpectedErr: errors.New("channel closed after 1 of 3 values\n - 2\n - 3"),
+		},
+		"not a channel": {
+			Input:       Args([]int{1}, []int{1}),
+			ExpectedErr: errors.New("type mismatch []int is not a channel"),
+		},
+	}).Test(t)
+}
+
+func TestEqualChan(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		b, s := EqualChan(10*time.Millisecond)(args[0], args[1])
+		var err error
+		if s != "" {
+			err = errors.New(s)
+		}
+		return b, err
+	}
+	produce := func(closed bool, values ...int) chan int {
+		ch := make(chan int, len(values))
+		for _, v := range values {
+			ch <- v
+		}
+		if closed {
+			close(ch)
+		}
+		return ch
+	}
+	New(fn, Cases{
+		"all values": {
+			Input:    Args(produce(true, 1, 2, 3), []int{1, 2, 3}),
+			Expected: true,
+		},
+		"extra value": {
+			Input:       Args(produce(true, 1, 2, 3), []int{1, 2}),
+			ExpectedErr: errors.New("[]int{"),
+		},
+		"empty channel": {
+			Input:    Args(produce(true), []int{}),
+			Expected: true,
+		},
+		"never closed": {
+			Input:       Args(produce(false, 1, 2), []int{1, 2}),
+			ExpectedErr: errors.New("channel not closed within 10ms, received [1 2]"),
+		},
+		"not a channel": {
+			Input:    Args([]int{1}, []int{1}),
+			Expected: true,
+		},
+	}).Test(t)
+
+	// values sent slowly are still received
+	ch := make(chan string)
+	go func() {
+		for _, s := range []string{"a", "b"} {
+			time.Sleep(time.Millisecond)
+			ch <- s
+		}
+		close(ch)
+	}()
+	if eq, diff := EqualChan(0)(ch, []string{"a", "b"}); !eq {
+		t.Error("FAIL: default timeout", diff)
+	}
+}
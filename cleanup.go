@@ -0,0 +1,59 @@
+package trial
+
+import (
+	"sync"
+)
+
+// TestFuncCleanup a wrapper function used to setup a method being tested that
+// returns resources which need to be released. The cleanup func may be nil.
+// An io.Closer can be returned as func() { c.Close() }
+type TestFuncCleanup func(args ...interface{}) (result interface{}, cleanup func(), err error)
+
+// NewWithCleanup creates a trial for a TestFuncCleanup. The cleanup returned from
+// fn is called after the result of the case has been checked, even when the case
+// fails or panics.
+func NewWithCleanup(fn TestFuncCleanup, cases map[string]Case) *Trial {
+	if fn == nil {
+		return New(nil, cases)
+	}
+	t := New(func(args ...interface{}) (interface{}, error) {
+		r, cleanup, err := fn(args...)
+		if cleanup != nil {
+			cleanup()
+		}
+		return r, err
+	}, cases)
+	t.cleanupFn = fn
+	return t
+}
+
+// cleanupFunc returns the TestFunc for a case that collects every cleanup
+// and a func that runs them in reverse order once the case has completed
+func (t *Trial) cleanupFunc() (TestFunc, func()) {
+	var mu sync.Mutex
+	var cleanups []func()
+	var completed bool
+	fn := func(args ...interface{}) (interface{}, error) {
+		r, cleanup, err := t.cleanupFn(args...)
+		if cleanup == nil {
+			return r, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if completed {
+			// the case timed out before the TestFunc returned
+			cleanup()
+		} else {
+			cleanups = append(cleanups, cleanup)
+		}
+		return r, err
+	}
+	return fn, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		completed = true
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package trial
+
+import (
+	"testing"
+)
+
+type resource struct {
+	name   string
+	closed bool
+}
+
+func TestNewWithCleanup(t *testing.T) {
+	var opened []*resource
+	fn := func(args ...interface{}) (interface{}, func(), error) {
+		r := &resource{name: args[0].(string)}
+		opened = append(opened, r)
+		if r.name == "panic" {
+			panic("open failed")
+		}
+		if r.name == "none" {
+			return r.name, nil, nil
+		}
+		return r, func() { r.closed = true }, nil
+	}
+	// the resource must still be open when it's compared,
+	// Deterministic runs are compared with the resource of the first run
+	open := func(actual, expected interface{}) (bool, string) {
+		r := actual.(*resource)
+		if e, ok := expected.(*resource); ok {
+			expected = e.name
+		}
+		return !r.closed && r.name == expected, "closed before compare"
+	}
+	cases := map[string]struct {
+		Case    Case
+		success bool
+		opened  int
+	}{
+		"closed after pass": {
+			Case:    Case{Input: "a", Expected: "a"},
+			success: true,
+			opened:  1,
+		},
+		"closed after fail": {
+			Case:   Case{Input: "a", Expected: "b"},
+			opened: 1,
+		},
+		"deterministic runs": {
+			Case:    Case{Input: "a", Expected: "a", Deterministic: 3},
+			success: true,
+			opened:  3,
+		},
+		"panic": {
+			Case:    Case{Input: "panic", ShouldPanic: true},
+			success: true,
+			opened:  1,
+		},
+	}
+	for msg, test := range cases {
+		opened = nil
+		r := NewWithCleanup(fn, nil).Comparer(open).testCase(msg, test.Case)
+		if r.Success != test.success || len(opened) != test.opened {
+			t.Errorf("FAIL: %q %d opened %s", msg, len(opened), r.Message)
+		}
+		for i, res := range opened {
+			if !res.closed && res.name != "panic" {
+				t.Errorf("FAIL: %q resource %d not closed", msg, i)
+			}
+		}
+	}
+
+	if r := NewWithCleanup(fn, nil).testCase("nil cleanup", Case{Input: "none", Expected: "none"}); !r.Success {
+		t.Error("FAIL:", r.Message)
+	}
+}
@@ -0,0 +1,33 @@
+package trial
+
+import "os"
+
+// Color forces the red coloring of failures on or off. By default failures are
+// colored when stdout is a terminal and the NO_COLOR environment variable is not set.
+func (t *Trial) Color(enabled bool) *Trial {
+	t.color = &enabled
+	return t
+}
+
+// red colors s when color output is enabled
+func (t *Trial) red(s string) string {
+	if t.color != nil && !*t.color {
+		return s
+	}
+	if t.color == nil && !colorTerminal() {
+		return s
+	}
+	return "\033[31m" + s + "\033[39m"
+}
+
+// colorTerminal checks if colors should be used when writing to stdout
+func colorTerminal() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,42 @@
+package trial
+
+import (
+	"os"
+	"testing"
+)
+
+func TestTrial_Color(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		tr := New(nil, nil)
+		if args[0] != nil {
+			tr.Color(args[0].(bool))
+		}
+		return tr.red("fail"), nil
+	}
+	New(fn, Cases{
+		"forced on": {
+			Input:    true,
+			Expected: "\033[31mfail\033[39m",
+		},
+		"forced off": {
+			Input:    false,
+			Expected: "fail",
+		},
+	}).Test(t)
+
+	prev, set := os.LookupEnv("NO_COLOR")
+	os.Setenv("NO_COLOR", "1")
+	defer func() {
+		if set {
+			os.Setenv("NO_COLOR", prev)
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+	}()
+	if s := New(nil, nil).red("fail"); s != "fail" {
+		t.Errorf("FAIL: NO_COLOR should disable colors %q", s)
+	}
+	if s := New(nil, nil).Color(true).red("fail"); s != "\033[31mfail\033[39m" {
+		t.Errorf("FAIL: Color(true) should override NO_COLOR %q", s)
+	}
+}
@@ -0,0 +1,38 @@
+package trial
+
+import (
+	"fmt"
+)
+
+// All combines comparers so actual and expected are only equal when every
+// comparer passes. The differences of each failing comparer are shown
+func All(fns ...CompareFunc) CompareFunc {
+	return func(actual, expected interface{}) (bool, string) {
+		s := ""
+		for i, fn := range fns {
+			if eq, diff := fn(actual, expected); !eq {
+				s += fmt.Sprintf("comparer[%d] failed", i) + indentNonEmpty(diff) + "\n"
+			}
+		}
+		return s == "", s
+	}
+}
+
+// Any combines comparers so actual and expected are equal when at least one
+// comparer passes. The differences of every comparer are shown when none pass
+func Any(fns ...CompareFunc) CompareFunc {
+	return func(actual, expected interface{}) (bool, string) {
+		s := ""
+		for i, fn := range fns {
+			eq, diff := fn(actual, expected)
+			if eq {
+				return true, ""
+			}
+			s += fmt.Sprintf("comparer[%d] failed", i) + indentNonEmpty(diff) + "\n"
+		}
+		if s == "" {
+			s = "no comparers\n"
+		}
+		return false, s
+	}
+}
@@ -0,0 +1,64 @@
+package trial
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAllAny(t *testing.T) {
+	length := func(actual, expected interface{}) (bool, string) {
+		if len(actual.(string)) > 10 {
+			return false, "longer than 10"
+		}
+		return true, ""
+	}
+	prefix := func(actual, expected interface{}) (bool, string) {
+		if !strings.HasPrefix(actual.(string), expected.(string)) {
+			return false, "missing prefix " + expected.(string)
+		}
+		return true, ""
+	}
+	cases := map[string]struct {
+		fn      CompareFunc
+		actual  string
+		equal   bool
+		message string
+	}{
+		"all pass": {
+			fn:     All(Contains, length),
+			actual: "hello",
+			equal:  true,
+		},
+		"all with one failing": {
+			fn:      All(Contains, length),
+			actual:  "hello world",
+			message: "comparer[1] failed\n  longer than 10\n",
+		},
+		"all with every failing": {
+			fn:      All(length, prefix),
+			actual:  "good day to you",
+			message: "comparer[0] failed\n  longer than 10\ncomparer[1] failed\n  missing prefix he\n",
+		},
+		"any with one passing": {
+			fn:     Any(length, Contains),
+			actual: "hello world",
+			equal:  true,
+		},
+		"any with none passing": {
+			fn:      Any(length, prefix),
+			actual:  "good day to you",
+			message: "comparer[0] failed\n  longer than 10\ncomparer[1] failed\n  missing prefix he\n",
+		},
+		"any without comparers": {
+			fn:      Any(),
+			actual:  "he",
+			message: "no comparers\n",
+		},
+	}
+	for msg, test := range cases {
+		eq, diff := test.fn(test.actual, "he")
+		if eq != test.equal || diff != test.message {
+			t.Errorf("FAIL: %q %v %q", msg, eq, diff)
+		}
+	}
+}
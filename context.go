@@ -0,0 +1,49 @@
+package trial
+
+import (
+	"context"
+)
+
+// TestFuncCtx a wrapper function used to setup a method being tested that takes a context.
+type TestFuncCtx func(ctx context.Context, args ...interface{}) (result interface{}, err error)
+
+// NewWithContext creates a trial for a TestFuncCtx. Each case is passed a new
+// context derived from the Case.Context, the trial's Context or context.Background().
+// The context is canceled when the case completes or its Timeout is reached.
+func NewWithContext(fn TestFuncCtx, cases map[string]Case) *Trial {
+	if fn == nil {
+		return New(nil, cases)
+	}
+	t := New(func(args ...interface{}) (interface{}, error) {
+		return fn(context.Background(), args...)
+	}, cases)
+	t.ctxFn = fn
+	return t
+}
+
+// Context sets the default parent context of every case created with NewWithContext
+func (t *Trial) Context(ctx context.Context) *Trial {
+	t.ctx = ctx
+	return t
+}
+
+// contextFunc returns the TestFunc for a case with its own context
+// and a func to cancel the context
+func (t *Trial) contextFunc(test Case) (TestFunc, func()) {
+	ctx := test.Context
+	if ctx == nil {
+		ctx = t.ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var cancel context.CancelFunc
+	if test.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, test.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	return func(args ...interface{}) (interface{}, error) {
+		return t.ctxFn(ctx, args...)
+	}, func() { cancel() }
+}
@@ -0,0 +1,64 @@
+package trial
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type ctxKey string
+
+func TestNewWithContext(t *testing.T) {
+	var mu sync.Mutex
+	var last context.Context
+	fn := func(ctx context.Context, args ...interface{}) (interface{}, error) {
+		mu.Lock()
+		last = ctx
+		mu.Unlock()
+		if args[0] == "wait" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return ctx.Value(ctxKey("user")), nil
+	}
+	withUser := func(user string) context.Context {
+		return context.WithValue(context.Background(), ctxKey("user"), user)
+	}
+	tr := NewWithContext(fn, nil).Context(withUser("default"))
+	cases := map[string]struct {
+		Case    Case
+		success bool
+		message string
+	}{
+		"case context": {
+			Case:    Case{Input: "value", Context: withUser("bob"), Expected: "bob"},
+			success: true,
+		},
+		"trial context": {
+			Case:    Case{Input: "value", Expected: "default"},
+			success: true,
+		},
+		"timeout cancels context": {
+			Case: Case{Input: "wait", Timeout: 10 * time.Millisecond},
+		},
+	}
+	for msg, test := range cases {
+		r := tr.testCase(msg, test.Case)
+		if r.Success != test.success || !strings.Contains(r.Message, test.message) {
+			t.Errorf("FAIL: %q %s", msg, r.Message)
+		}
+		mu.Lock()
+		ctx := last
+		mu.Unlock()
+		if ctx.Err() == nil {
+			t.Errorf("FAIL: %q context not canceled after the case", msg)
+		}
+	}
+
+	r := NewWithContext(fn, nil).testCase("background", Case{Input: "value", Expected: nil})
+	if !r.Success {
+		t.Error("FAIL: background context", r.Message)
+	}
+}
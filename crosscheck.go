@@ -0,0 +1,67 @@
+package trial
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// CrossCheckComparers compares the result of every case with each of the
+// comparers in addition to the trial's comparer and fails the case when they
+// disagree on whether it passes. This is used to verify a looser comparer
+// isn't hiding differences that a stricter one catches, or the reverse.
+func (t *Trial) CrossCheckComparers(fns ...CompareFunc) *Trial {
+	t.crossCheck = append(t.crossCheck, fns...)
+	return t
+}
+
+// checkComparers reports when any cross check comparer disagrees with the trial's comparer.
+// Expected values that are a Comparer, Validator or AnyOf are matched the same for every comparer
+func (t *Trial) checkComparers(msg string, actual, expected interface{}, equal bool, diff string) result {
+	s := ""
+	for i, fn := range t.crossCheck {
+		eq, d := t.compareWith(fn, actual, expected)
+		if eq == equal {
+			continue
+		}
+		s += fmt.Sprintf("\ncomparer[%d] %s %s", i, funcName(fn), passFail(eq))
+		if d != "" {
+			s += "\n" + indent(d)
+		}
+	}
+	if s == "" {
+		return pass("PASS: %q", msg)
+	}
+	s = fmt.Sprintf("trial comparer %s", passFail(equal)) + indentNonEmpty(diff) + s
+	return fail("FAIL: %q comparers disagree\n%s", msg, s)
+}
+
+func passFail(b bool) string {
+	if b {
+		return "passed"
+	}
+	return "failed"
+}
+
+// funcName is the name of the function fn
+func funcName(fn interface{}) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return "unknown"
+	}
+	name := f.Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// indent each line of s
+func indent(s string) string {
+	return "  " + strings.Replace(strings.TrimRight(s, "\n"), "\n", "\n  ", -1)
+}
+
+func indentNonEmpty(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "\n" + indent(s)
+}
@@ -0,0 +1,61 @@
+package trial
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrial_CrossCheckComparers(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	cases := map[string]struct {
+		trial   *Trial
+		Case    Case
+		success bool
+		message string
+	}{
+		"comparers agree on pass": {
+			trial:   New(fn, nil).CrossCheckComparers(RoundFloats(2)),
+			Case:    Case{Input: 1.5, Expected: 1.5},
+			success: true,
+		},
+		"comparers agree on fail": {
+			trial:   New(fn, nil).CrossCheckComparers(RoundFloats(2)),
+			Case:    Case{Input: 1.5, Expected: 2.5},
+			success: false,
+			message: "\n",
+		},
+		"looser comparer hides difference": {
+			trial:   New(fn, nil).CrossCheckComparers(RoundFloats(2)),
+			Case:    Case{Input: 1.501, Expected: 1.5},
+			success: false,
+			message: "comparers disagree\ntrial comparer failed",
+		},
+		"stricter comparer finds difference": {
+			trial:   New(fn, nil).Comparer(RoundFloats(2)).CrossCheckComparers(Equal),
+			Case:    Case{Input: 1.501, Expected: 1.5},
+			success: false,
+			message: "comparer[0] trial.Equal failed",
+		},
+		"panicking comparer": {
+			trial: New(fn, nil).CrossCheckComparers(func(actual, expected interface{}) (bool, string) {
+				panic("bad comparer")
+			}),
+			Case:    Case{Input: 1, Expected: 1},
+			success: false,
+			message: "comparer panic: bad comparer",
+		},
+		"matcher expected": {
+			trial:   New(fn, nil).CrossCheckComparers(Equal),
+			Case:    Case{Input: 3, Expected: Positive},
+			success: true,
+		},
+	}
+	for msg, test := range cases {
+		r := test.trial.testCase(msg, test.Case)
+		if r.Success != test.success || !strings.Contains(r.Message, test.message) {
+			t.Errorf("FAIL: %q %s", msg, r.Message)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package trial
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// DerefPointers displays each difference with all pointers dereferenced
+// so the pointed to values are shown instead of addresses. nil pointers
+// are shown as nil and cyclic pointers as <cycle>.
+// This replaces any Reporter or TreeDiff set on the trial.
+// Only used with the default Equal comparer.
+func (t *Trial) DerefPointers() *Trial {
+	return t.Reporter(func() DiffReporter { return &derefReporter{} })
+}
+
+// derefReporter is a DiffReporter that lists the path to each difference
+// with the values fully dereferenced
+type derefReporter struct {
+	path  cmp.Path
+	diffs []string
+}
+
+func (r *derefReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *derefReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *derefReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	name := fmt.Sprint(r.path.Index(0).Type())
+	for _, step := range r.path[1:] {
+		switch step.(type) {
+		case cmp.StructField, cmp.SliceIndex, cmp.MapIndex:
+			name += step.String()
+		}
+	}
+	vx, vy := r.path.Last().Values()
+	r.diffs = append(r.diffs, fmt.Sprintf("%s: %s, expected: %s",
+		name, derefValue(vx, map[uintptr]bool{}), derefValue(vy, map[uintptr]bool{})))
+}
+
+func (r *derefReporter) String() string {
+	return strings.Join(r.diffs, "\n")
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// derefValue formats v following all pointers. visited tracks the
+// pointers in the current path to stop cycles
+func derefValue(v reflect.Value, visited map[uintptr]bool) string {
+	if !v.IsValid() {
+		return "<missing>"
+	}
+	if v.Kind() != reflect.Ptr && v.CanInterface() && v.Type().Implements(stringerType) {
+		return v.Interface().(fmt.Stringer).String()
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "nil"
+		}
+		if visited[v.Pointer()] {
+			return "<cycle>"
+		}
+		visited[v.Pointer()] = true
+		defer delete(visited, v.Pointer())
+		return "&" + derefValue(v.Elem(), visited)
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return derefValue(v.Elem(), visited)
+	case reflect.Struct:
+		fields := make([]string, v.NumField())
+		for i := range fields {
+			fields[i] = v.Type().Field(i).Name + ": " + derefValue(v.Field(i), visited)
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return "nil"
+		}
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = derefValue(v.Index(i), visited)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	case reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+		values := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			values = append(values, derefValue(key, visited)+": "+derefValue(v.MapIndex(key), visited))
+		}
+		sort.Strings(values)
+		return "map[" + strings.Join(values, ", ") + "]"
+	case reflect.String:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprintf("%v", v)
+}
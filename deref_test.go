@@ -0,0 +1,46 @@
+package trial
+
+import (
+	"testing"
+)
+
+func TestDerefReporter(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+	}
+	type record struct {
+		Name  *string
+		Child *node
+		tags  map[string]*int
+	}
+	cycle := &node{Value: 1}
+	cycle.Next = cycle
+
+	fn := func(args ...interface{}) (interface{}, error) {
+		_, s := reportEqual(args[0], args[1], &derefReporter{})
+		return s, nil
+	}
+	New(fn, Cases{
+		"equal": {
+			Input:    Args(&node{Value: 1}, &node{Value: 1}),
+			Expected: "",
+		},
+		"nil and non-nil pointer": {
+			Input:    Args(record{}, record{Name: StringP("a")}),
+			Expected: `trial.record.Name: nil, expected: &"a"`,
+		},
+		"nested pointer values": {
+			Input:    Args(record{Child: &node{Value: 1}}, record{Child: &node{Value: 1, Next: &node{Value: 2}}}),
+			Expected: "trial.record.Child.Next: nil, expected: &{Value: 2, Next: nil}",
+		},
+		"unexported map of pointers": {
+			Input:    Args(record{tags: map[string]*int{"a": IntP(1)}}, record{tags: map[string]*int{"a": IntP(2)}}),
+			Expected: `trial.record.tags["a"]: 1, expected: 2`,
+		},
+		"cyclic pointer": {
+			Input:    Args(&node{Value: 1}, cycle),
+			Expected: "*trial.node.Next: nil, expected: &{Value: 1, Next: <cycle>}",
+		},
+	}).Test(t)
+}
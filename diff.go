@@ -0,0 +1,53 @@
+package trial
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diff builds a human readable description of the differences between
+// an actual and expected value. It can be used by custom CompareFuncs to
+// produce output consistent with the trial comparers.
+// Symbols with meaning:
+// "-" elements missing from actual
+// "+" elements missing from expected
+type Diff struct {
+	lines []string
+}
+
+// NewDiff creates an empty Diff
+func NewDiff() *Diff {
+	return &Diff{lines: make([]string, 0)}
+}
+
+// Missing records values that were expected but not found in actual
+func (d *Diff) Missing(values ...interface{}) *Diff {
+	for _, v := range values {
+		d.lines = append(d.lines, fmt.Sprintf(" - %v", v))
+	}
+	return d
+}
+
+// Extra records values found in actual that were not expected
+func (d *Diff) Extra(values ...interface{}) *Diff {
+	for _, v := range values {
+		d.lines = append(d.lines, fmt.Sprintf(" + %v", v))
+	}
+	return d
+}
+
+// Errorf records a custom formatted message
+func (d *Diff) Errorf(format string, args ...interface{}) *Diff {
+	d.lines = append(d.lines, fmt.Sprintf(format, args...))
+	return d
+}
+
+// Empty returns true if no differences have been recorded
+func (d *Diff) Empty() bool {
+	return len(d.lines) == 0
+}
+
+// String renders all recorded differences, one per line
+func (d *Diff) String() string {
+	return strings.Join(d.lines, "\n")
+}
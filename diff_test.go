@@ -0,0 +1,34 @@
+package trial
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0].(*Diff).String(), nil
+	}
+	cases := Cases{
+		"empty diff": {
+			Input:    NewDiff(),
+			Expected: "",
+		},
+		"missing values": {
+			Input:    NewDiff().Missing(1, 2),
+			Expected: " - 1\n - 2",
+		},
+		"extra values": {
+			Input:    NewDiff().Extra("a"),
+			Expected: " + a",
+		},
+		"custom message": {
+			Input:    NewDiff().Errorf("length %d, expected %d", 1, 2),
+			Expected: "length 1, expected 2",
+		},
+		"combined": {
+			Input:    NewDiff().Extra("abc").Missing("xyz"),
+			Expected: " + abc\n - xyz",
+		},
+	}
+	New(fn, cases).Test(t)
+}
@@ -0,0 +1,56 @@
+package trial
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AssertAllDistinct checks that no two cases produced an equal result
+// using the trial's comparer. This is checked after all cases have run.
+func (t *Trial) AssertAllDistinct() *Trial {
+	t.distinct = &results{values: make(map[string]interface{})}
+	return t
+}
+
+// results collects the result of each case
+type results struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+func (r *results) add(name string, value interface{}) {
+	r.mu.Lock()
+	r.values[name] = value
+	r.mu.Unlock()
+}
+
+// checkDistinct compares the results of all cases with each other
+// and reports any cases that returned the same value
+func (t *Trial) checkDistinct() result {
+	if t.distinct == nil {
+		return pass("PASS: all results distinct")
+	}
+	t.distinct.mu.Lock()
+	defer t.distinct.mu.Unlock()
+	names := make([]string, 0, len(t.distinct.values))
+	for name := range t.distinct.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var collisions []string
+	for i, a := range names {
+		for _, b := range names[i+1:] {
+			x, y := t.distinct.values[a], t.distinct.values[b]
+			if eq, _ := t.compare(x, y); eq {
+				collisions = append(collisions, fmt.Sprintf(" %q and %q: %v", a, b, x))
+			}
+		}
+	}
+	if len(collisions) > 0 {
+		return fail("FAIL: results not distinct\n%s", strings.Join(collisions, "\n"))
+	}
+	return pass("PASS: all results distinct")
+}
@@ -0,0 +1,40 @@
+package trial
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTrial_AssertAllDistinct(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return len(args[0].(string)), nil
+	}
+
+	tr := New(fn, nil).AssertAllDistinct()
+	tr.testCase("a", Case{Input: "a", Expected: 1})
+	tr.testCase("abc", Case{Input: "abc", Expected: 3})
+	if r := tr.checkDistinct(); !r.Success {
+		t.Errorf("FAIL: distinct results %s", r.Message)
+	}
+
+	tr.testCase("xyz", Case{Input: "xyz", Expected: 3})
+	r := tr.checkDistinct()
+	if r.Success || !strings.Contains(r.Message, `"abc" and "xyz": 3`) {
+		t.Errorf("FAIL: colliding results not reported %q", r.Message)
+	}
+
+	if r := New(fn, nil).checkDistinct(); !r.Success {
+		t.Error("FAIL: distinct check should be skipped by default")
+	}
+
+	failing := func(args ...interface{}) (interface{}, error) {
+		return nil, errors.New("bad input")
+	}
+	tr = New(failing, nil).AssertAllDistinct()
+	tr.testCase("a", Case{Input: "a", ShouldErr: true})
+	tr.testCase("b", Case{Input: "b", ShouldErr: true})
+	if r := tr.checkDistinct(); !r.Success {
+		t.Errorf("FAIL: errors should not be checked %s", r.Message)
+	}
+}
@@ -0,0 +1,38 @@
+package trial
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ShowElapsed adds the time taken to the PASS message of each case that took
+// at least min to run, eg `PASS: "name" (1.2ms)`. Use 0 to show the time of every case
+func (t *Trial) ShowElapsed(min time.Duration) *Trial {
+	t.slow = &min
+	return t
+}
+
+// withElapsed adds the elapsed time to the first line of a passing result
+func (t *Trial) withElapsed(r result, elapsed time.Duration) result {
+	if t.slow == nil || !r.Success || r.skipped() || elapsed < *t.slow {
+		return r
+	}
+	lines := strings.SplitN(r.Message, "\n", 2)
+	lines[0] += fmt.Sprintf(" (%v)", roundElapsed(elapsed))
+	r.Message = strings.Join(lines, "\n")
+	return r
+}
+
+// roundElapsed rounds d to 2 decimal places of its unit (1.23ms, 4.5s)
+func roundElapsed(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	case d >= time.Microsecond:
+		return d.Round(10 * time.Nanosecond)
+	}
+	return d
+}
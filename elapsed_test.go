@@ -0,0 +1,57 @@
+package trial
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestTrial_ShowElapsed(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		time.Sleep(args[0].(time.Duration))
+		return nil, nil
+	}
+	cases := Cases{
+		"fast": {Input: time.Duration(0)},
+		"slow": {Input: 20 * time.Millisecond},
+		"fail": {Input: 20 * time.Millisecond, ShouldErr: true},
+		"skip": {Skip: true},
+	}
+	messages := func(results []Result) map[string]string {
+		m := make(map[string]string)
+		for _, r := range results {
+			m[r.Name] = r.Message
+		}
+		return m
+	}
+
+	results := New(fn, cases).ShowElapsed(10 * time.Millisecond).Run()
+	for _, r := range results {
+		if r.Name == "slow" && r.Duration < 20*time.Millisecond {
+			t.Errorf("FAIL: result duration %v", r.Duration)
+		}
+	}
+	m := messages(results)
+	if m["fast"] != `PASS: "fast"` {
+		t.Errorf("FAIL: fast case should not show elapsed %q", m["fast"])
+	}
+	if !regexp.MustCompile(`^PASS: "slow" \(\d+(\.\d+)?ms\)$`).MatchString(m["slow"]) {
+		t.Errorf("FAIL: slow case should show elapsed %q", m["slow"])
+	}
+	if m["fail"] != `FAIL: "fail" should error` || m["skip"] != `SKIP: "skip" skipped` {
+		t.Errorf("FAIL: only passing cases show elapsed %q %q", m["fail"], m["skip"])
+	}
+
+	m = messages(New(fn, cases).ShowElapsed(0).Run())
+	if !regexp.MustCompile(`^PASS: "fast" \(.+\)$`).MatchString(m["fast"]) {
+		t.Errorf("FAIL: all cases should show elapsed %q", m["fast"])
+	}
+	if m = messages(New(fn, cases).Run()); m["slow"] != `PASS: "slow"` {
+		t.Errorf("FAIL: elapsed shown by default %q", m["slow"])
+	}
+
+	r := New(nil, nil).ShowElapsed(0).withElapsed(pass("PASS: %q with allowed differences\n-a", "d"), 1234567*time.Nanosecond)
+	if r.Message != "PASS: \"d\" with allowed differences (1.23ms)\n-a" {
+		t.Errorf("FAIL: elapsed on first line %q", r.Message)
+	}
+}
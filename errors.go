@@ -0,0 +1,178 @@
+package trial
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// errDetail describes the actual error when more information is
+// needed to understand why it didn't match the expected error
+func errDetail(actual, expected error) string {
+	switch expected.(type) {
+	case errChain, errIs, errAs:
+		return "\n" + errorChain(actual)
+	}
+	return ""
+}
+
+type errTemplate struct {
+	format string
+	verbs  bool
+}
+
+func (e errTemplate) Error() string {
+	return e.format
+}
+
+// ErrTemplate can be used with ExpectedErr to check for an error message
+// that contains the case's Input. {{input}} is replaced with the Input value
+func ErrTemplate(format string) error {
+	return errTemplate{format: format}
+}
+
+// ErrTemplatef is like ErrTemplate and also fills the fmt verbs (%v, %d)
+// in format with the Input values (see Args). Use %% for a literal percent sign
+func ErrTemplatef(format string) error {
+	return errTemplate{format: format, verbs: true}
+}
+
+// expectedError renders the expected error for the input of a case
+func expectedError(expected error, input interface{}) error {
+	if expected == NoError {
+		return nil
+	}
+	tmpl, ok := expected.(errTemplate)
+	if !ok {
+		return expected
+	}
+	s := tmpl.format
+	if tmpl.verbs {
+		if args, ok := input.([]interface{}); ok {
+			s = fmt.Sprintf(s, args...)
+		} else {
+			s = fmt.Sprintf(s, input)
+		}
+	}
+	return errors.New(strings.Replace(s, "{{input}}", fmt.Sprint(input), -1))
+}
+
+type noError struct{}
+
+func (noError) Error() string {
+	return "no error"
+}
+
+// NoError can be used with ExpectedErr to make it clear the case must not
+// return an error. It's the same as leaving ShouldErr and ExpectedErr unset
+var NoError error = noError{}
+
+type errChain struct {
+	target error
+}
+
+func (e errChain) Error() string {
+	return e.target.Error()
+}
+
+// ErrContains can be used with ExpectedErr to check that target
+// is anywhere in the error's chain using errors.Is. Both Unwrap() error
+// and Unwrap() []error (errors.Join) are followed.
+// The full chain is shown when the target is not found
+func ErrContains(target error) error {
+	return errChain{target}
+}
+
+type errMsg string
+
+func (e errMsg) Error() string {
+	return string(e)
+}
+
+// ErrMsgContains can be used with ExpectedErr to make it clear the error
+// message only needs to contain substr. A plain error does the same but
+// also matches with errors.Is
+func ErrMsgContains(substr string) error {
+	return errMsg(substr)
+}
+
+type errIs struct {
+	target error
+}
+
+func (e errIs) Error() string {
+	return e.target.Error()
+}
+
+// ErrIs can be used with ExpectedErr to check the error matches target
+// using errors.Is only, without falling back to comparing the error messages
+func ErrIs(target error) error {
+	return errIs{target}
+}
+
+type errAs struct {
+	typ reflect.Type
+}
+
+func (e errAs) Error() string {
+	return fmt.Sprint(e.typ)
+}
+
+// ErrAs can be used with ExpectedErr to check that the error chain has an
+// error of the same type as target using errors.As.
+// eg: ErrAs(&net.OpError{}) matches a wrapped *net.OpError.
+// A pointer to an interface (eg (*net.Error)(nil)) matches any error implementing it
+func ErrAs(target interface{}) error {
+	typ := reflect.TypeOf(target)
+	if typ == nil {
+		panic("trial: ErrAs target must not be nil")
+	}
+	if typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Interface {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Interface && !typ.Implements(errorType) {
+		panic(fmt.Sprintf("trial: ErrAs target %v must be an error or a pointer to an interface", typ))
+	}
+	return errAs{typ}
+}
+
+type errEqual struct {
+	target error
+}
+
+func (e errEqual) Error() string {
+	return e.target.Error()
+}
+
+// ErrEqual can be used with ExpectedErr to compare the error with target
+// using the trial's comparer (Equal by default) instead of matching the message.
+// The fields of custom error types are compared and their differences shown
+func ErrEqual(target error) error {
+	return errEqual{target}
+}
+
+// walkErr calls fn for err and every error it wraps
+func walkErr(err error, depth int, fn func(err error, depth int)) {
+	if err == nil {
+		return
+	}
+	fn(err, depth)
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		walkErr(e.Unwrap(), depth+1, fn)
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			walkErr(child, depth+1, fn)
+		}
+	}
+}
+
+// errorChain displays err and all the errors it wraps as an indented list
+func errorChain(err error) string {
+	s := "error chain:"
+	walkErr(err, 1, func(e error, depth int) {
+		s += fmt.Sprintf("\n%s%T: %v", strings.Repeat("  ", depth), e, e)
+	})
+	return s
+}
@@ -0,0 +1,42 @@
+package trial
+
+import (
+	"sync"
+)
+
+// SharedFixture sets up a fixture that is shared by all cases. The fixture
+// is created once when the first case is run and passed to the TestFunc
+// as the first argument (args[0]). The returned teardown func is called
+// after all cases have completed.
+// The fixture should be treated as read-only as cases are not isolated from each other
+func (t *Trial) SharedFixture(setup func() (interface{}, func())) *Trial {
+	t.fixture = &fixture{setup: setup}
+	return t
+}
+
+type fixture struct {
+	setup func() (interface{}, func())
+	once  sync.Once
+	value interface{}
+	close func()
+}
+
+// get the fixture, creating it on first use
+func (f *fixture) get() interface{} {
+	f.once.Do(func() {
+		f.value, f.close = f.setup()
+	})
+	return f.value
+}
+
+// teardown the fixture if it was created so the next run creates a new one
+func (f *fixture) teardown() {
+	if f == nil {
+		return
+	}
+	if f.close != nil {
+		f.close()
+	}
+	f.once = sync.Once{}
+	f.value, f.close = nil, nil
+}
@@ -0,0 +1,50 @@
+package trial
+
+import (
+	"testing"
+)
+
+func TestTrial_SharedFixture(t *testing.T) {
+	var setups, teardowns int
+	fn := func(args ...interface{}) (interface{}, error) {
+		m := args[0].(map[string]int)
+		if args[1] == "panic" {
+			panic("case panic")
+		}
+		return m[args[1].(string)], nil
+	}
+	tr := New(fn, Cases{
+		"a": {Input: "a", Expected: 1},
+		"b": {Input: "b", Expected: 2},
+		"panic": {
+			Input:       "panic",
+			ShouldPanic: true,
+		},
+	}).SharedFixture(func() (interface{}, func()) {
+		setups++
+		return map[string]int{"a": 1, "b": 2}, func() { teardowns++ }
+	})
+	tr.Test(t)
+
+	if setups != 1 || teardowns != 1 {
+		t.Errorf("FAIL: fixture setup %d times and teardown %d times", setups, teardowns)
+	}
+
+	// a second run gets a new fixture
+	tr.Test(t)
+	if setups != 2 || teardowns != 2 {
+		t.Errorf("FAIL: fixture reused after teardown, setup %d times and teardown %d times", setups, teardowns)
+	}
+}
+
+func TestTrial_SharedFixtureLazy(t *testing.T) {
+	var setups int
+	fn := func(args ...interface{}) (interface{}, error) { return nil, nil }
+	New(fn, nil).SharedFixture(func() (interface{}, func()) {
+		setups++
+		return nil, nil
+	}).Test(t)
+	if setups != 0 {
+		t.Error("FAIL: fixture should not be created without cases")
+	}
+}
@@ -0,0 +1,154 @@
+package trial
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// FloatFormat is the fmt verb used to display floats in the differences
+// reported by ApproxEqual and ApproxULP, eg "%.4f". It doesn't change the comparison
+var FloatFormat = "%v"
+
+// formatFloat displays f with the FloatFormat
+func formatFloat(f float64) string {
+	return fmt.Sprintf(FloatFormat, f)
+}
+
+// RoundFloats compares actual and expected like Equal after rounding all
+// float values to the number of decimal places. This includes floats
+// nested in structs, slices and maps. The rounded values are shown in the diff.
+func RoundFloats(decimals int) CompareFunc {
+	pow := math.Pow(10, float64(decimals))
+	round := func(f float64) float64 {
+		return math.Round(f*pow) / pow
+	}
+	opts := []cmp.Option{
+		cmp.Transformer("RoundFloats", round),
+		cmp.Transformer("RoundFloats", func(f float32) float32 {
+			return float32(round(float64(f)))
+		}),
+	}
+	return func(actual, expected interface{}) (bool, string) {
+		return equal(actual, expected, opts...)
+	}
+}
+
+// ApproxEqual compares actual and expected like Equal with floats considered
+// equal when they are within the epsilon of each other.
+// This includes floats nested in structs, slices and maps. The path of each float
+// that exceeds the tolerance is reported along with its difference
+func ApproxEqual(epsilon float64) CompareFunc {
+	return func(actual, expected interface{}) (bool, string) {
+		d := NewDiff()
+		seen := make(map[string]bool)
+		within := cmp.FilterPath(func(p cmp.Path) bool {
+			vx, vy := p.Last().Values()
+			if !vx.IsValid() || !vy.IsValid() || vx.Kind() != vy.Kind() {
+				return false
+			}
+			if k := vx.Kind(); k != reflect.Float64 && k != reflect.Float32 {
+				return false
+			}
+			x, y := vx.Float(), vy.Float()
+			if x == y || math.Abs(x-y) <= epsilon {
+				return true
+			}
+			s := fmt.Sprintf("%s%s, expected %s (differs by %s)", pathName(p), formatFloat(x), formatFloat(y), formatFloat(math.Abs(x-y)))
+			if !seen[s] {
+				seen[s] = true
+				d.Errorf("%s", s)
+			}
+			return false
+		}, cmp.Ignore())
+		if eq, diff := equal(actual, expected, within); !eq {
+			return false, diff + "\n" + d.String()
+		}
+		return true, ""
+	}
+}
+
+// pathName is the path to a value (eg "Inner.Values[1]: ") or empty for the root value
+func pathName(p cmp.Path) string {
+	s := ""
+	for _, step := range p {
+		switch ps := step.(type) {
+		case cmp.StructField:
+			s += "." + ps.Name()
+		case cmp.SliceIndex:
+			s += fmt.Sprintf("[%d]", ps.Key())
+		case cmp.MapIndex:
+			s += fmt.Sprintf("[%#v]", ps.Key())
+		}
+	}
+	if s == "" {
+		return ""
+	}
+	return strings.TrimPrefix(s, ".") + ": "
+}
+
+// ApproxULP compares actual and expected like Equal with floats considered equal
+// when they are within maxULPs units in the last place of each other.
+// This includes floats nested in structs, slices and maps. The ULP distance
+// of each float outside of the limit is reported. NaN is never equal
+func ApproxULP(maxULPs int) CompareFunc {
+	return func(actual, expected interface{}) (bool, string) {
+		d := NewDiff()
+		dists := make(map[string]bool)
+		within := func(a, b float64, dist uint64) bool {
+			if a == b {
+				return true
+			}
+			if math.IsNaN(a) || math.IsNaN(b) || dist > uint64(maxULPs) {
+				s := fmt.Sprintf("%s, expected %s (%d ulps)", formatFloat(a), formatFloat(b), dist)
+				if !dists[s] {
+					dists[s] = true
+					d.Errorf("%s", s)
+				}
+				return false
+			}
+			return true
+		}
+		opts := []cmp.Option{
+			cmp.Comparer(func(a, b float64) bool {
+				return within(a, b, ulps(orderedBits64(a), orderedBits64(b)))
+			}),
+			cmp.Comparer(func(a, b float32) bool {
+				return within(float64(a), float64(b), ulps(orderedBits32(a), orderedBits32(b)))
+			}),
+		}
+		if eq, diff := equal(actual, expected, opts...); !eq {
+			return false, diff + "\n" + d.String()
+		}
+		return true, ""
+	}
+}
+
+// orderedBits64 maps f to an integer where adjacent floats differ by 1
+func orderedBits64(f float64) int64 {
+	i := int64(math.Float64bits(f))
+	if i < 0 {
+		i = math.MinInt64 - i
+	}
+	return i
+}
+
+// orderedBits32 maps f to an integer where adjacent floats differ by 1
+func orderedBits32(f float32) int64 {
+	i := int64(int32(math.Float32bits(f)))
+	if i < 0 {
+		i = math.MinInt32 - i
+	}
+	return i
+}
+
+// ulps is the distance between two ordered float representations
+func ulps(a, b int64) uint64 {
+	if a > b {
+		return uint64(a) - uint64(b)
+	}
+	return uint64(b) - uint64(a)
+}
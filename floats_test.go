@@ -0,0 +1,165 @@
+package trial
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRoundFloats(t *testing.T) {
+	type measure struct {
+		Value  float64
+		Ratios []float32
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, diff := RoundFloats(2)(args[0], args[1])
+		return eq && diff == "", nil
+	}
+	New(fn, Cases{
+		"floats round to equal": {
+			Input:    Args(1.234, 1.23),
+			Expected: true,
+		},
+		"floats round up": {
+			Input:    Args(1.235, 1.24),
+			Expected: true,
+		},
+		"rounded values differ": {
+			Input:    Args(1.3, 1.2),
+			Expected: false,
+		},
+		"nested floats": {
+			Input: Args(
+				measure{Value: 3.14159, Ratios: []float32{0.333333, 0.666666}},
+				measure{Value: 3.14, Ratios: []float32{0.33, 0.67}},
+			),
+			Expected: true,
+		},
+		"map of floats": {
+			Input:    Args(map[string]float64{"a": 0.001}, map[string]float64{"a": 0}),
+			Expected: true,
+		},
+	}).Test(t)
+
+	if _, diff := RoundFloats(1)(1.26, 1.2); !strings.Contains(diff, "1.3") {
+		t.Errorf("FAIL: rounded value not shown in diff %q", diff)
+	}
+}
+
+func TestApproxEqual(t *testing.T) {
+	type measure struct {
+		Value  float64
+		Ratios []float32
+		Names  map[string]float64
+		Label  string
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, diff := ApproxEqual(1e-6)(args[0], args[1])
+		return eq && diff == "", nil
+	}
+	New(fn, Cases{
+		"within epsilon": {
+			Input:    Args(0.1+0.2, 0.3),
+			Expected: true,
+		},
+		"outside epsilon": {
+			Input:    Args(1.001, 1.0),
+			Expected: false,
+		},
+		"nested floats": {
+			Input: Args(
+				measure{Value: 1.0000001, Ratios: []float32{0.5000001}, Names: map[string]float64{"a": 2.0000001}, Label: "x"},
+				measure{Value: 1, Ratios: []float32{0.5}, Names: map[string]float64{"a": 2}, Label: "x"},
+			),
+			Expected: true,
+		},
+		"non float field differs": {
+			Input:    Args(measure{Value: 1, Label: "x"}, measure{Value: 1, Label: "y"}),
+			Expected: false,
+		},
+	}).Test(t)
+
+	_, diff := ApproxEqual(0.01)(measure{Ratios: []float32{1, 2}}, measure{Ratios: []float32{1, 2.5}})
+	if !strings.Contains(diff, "Ratios[1]: 2, expected 2.5 (differs by 0.5)") {
+		t.Errorf("FAIL: field exceeding tolerance not reported %q", diff)
+	}
+}
+
+func TestApproxULP(t *testing.T) {
+	type measure struct {
+		Value  float64
+		Ratios []float32
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, diff := ApproxULP(4)(args[0], args[1])
+		return eq && diff == "", nil
+	}
+	next := func(f float64, n int) float64 {
+		for i := 0; i < n; i++ {
+			f = math.Nextafter(f, math.Inf(1))
+		}
+		return f
+	}
+	New(fn, Cases{
+		"exact": {
+			Input:    Args(1.5, 1.5),
+			Expected: true,
+		},
+		"within ulps": {
+			Input:    Args(next(1, 4), 1.0),
+			Expected: true,
+		},
+		"outside ulps": {
+			Input:    Args(next(1, 5), 1.0),
+			Expected: false,
+		},
+		"sum rounding": {
+			Input:    Args(0.1+0.2, 0.3),
+			Expected: true,
+		},
+		"across zero": {
+			Input:    Args(math.Copysign(0, -1), 0.0),
+			Expected: true,
+		},
+		"negative within ulps": {
+			Input:    Args(-next(1, 2), -1.0),
+			Expected: true,
+		},
+		"nan": {
+			Input:    Args(math.NaN(), math.NaN()),
+			Expected: false,
+		},
+		"nested floats": {
+			Input: Args(
+				measure{Value: next(3, 1), Ratios: []float32{math.Nextafter32(0.5, 1)}},
+				measure{Value: 3, Ratios: []float32{0.5}},
+			),
+			Expected: true,
+		},
+		"map of floats": {
+			Input:    Args(map[string]float64{"a": 1.01}, map[string]float64{"a": 1}),
+			Expected: false,
+		},
+	}).Test(t)
+
+	if _, diff := ApproxULP(1)(next(2, 3), 2.0); !strings.Contains(diff, "(3 ulps)") {
+		t.Errorf("FAIL: ulp distance not reported %q", diff)
+	}
+}
+
+func TestFloatFormat(t *testing.T) {
+	defer func(f string) { FloatFormat = f }(FloatFormat)
+	FloatFormat = "%.4f"
+
+	_, diff := ApproxEqual(0.001)([]float64{1.0 / 3}, []float64{0.5})
+	if !strings.Contains(diff, "[0]: 0.3333, expected 0.5000 (differs by 0.1667)") {
+		t.Errorf("FAIL: ApproxEqual format\n%s", diff)
+	}
+	_, diff = ApproxULP(1)(2.0/3, 0.7)
+	if !strings.Contains(diff, "0.6667, expected 0.7000 (") {
+		t.Errorf("FAIL: ApproxULP format\n%s", diff)
+	}
+	if eq, _ := ApproxEqual(0.001)(1.00001, 1.00002); !eq {
+		t.Error("FAIL: FloatFormat should not change the comparison")
+	}
+}
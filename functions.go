@@ -21,14 +21,83 @@ func Contains(x, y interface{}) (bool, string) {
 	if y == nil {
 		return true, ""
 	}
-	r := contains(x, y)
+	r := contains(x, y, false)
 	if r == nil {
 		return true, ""
 	}
 	return false, r.String()
 }
 
-func contains(x, y interface{}) differ {
+// ContainsInsensitive determines if y is a subset of x like Contains
+// but strings and string map keys are compared case-insensitively.
+// The differences show the strings with their original case
+func ContainsInsensitive(x, y interface{}) (bool, string) {
+	if y == nil {
+		return true, ""
+	}
+	r := contains(x, y, true)
+	if r == nil {
+		return true, ""
+	}
+	return false, r.String()
+}
+
+// NotContains determines if no part of y is found in x.
+// x is a string -> y is a string that is not in x
+// x is a slice or array -> none of the elements of y are in x
+// x is a map -> y is a map and none of its key/value pairs are in x
+// Any values of y that were found are shown as unexpected (+)
+func NotContains(x, y interface{}) (bool, string) {
+	if y == nil {
+		return true, ""
+	}
+	valX, valY := reflect.ValueOf(x), reflect.ValueOf(y)
+	d := NewDiff()
+	switch valX.Kind() {
+	case reflect.String:
+		s, ok := y.(string)
+		if v, isStringer := y.(fmt.Stringer); !ok && isStringer {
+			s, ok = v.String(), true
+		}
+		if !ok {
+			return false, fmt.Sprintf("type mismatch %T %T", x, y)
+		}
+		if strings.Contains(valX.String(), s) {
+			d.Extra(s)
+		}
+	case reflect.Array, reflect.Slice:
+		values := []interface{}{y}
+		if isList(valY) {
+			values = listValues(valY)
+		}
+		for _, v := range values {
+			if isInSlice(false, valX, v) == nil {
+				d.Extra(v)
+			}
+		}
+	case reflect.Map:
+		if valY.Kind() != reflect.Map {
+			return false, fmt.Sprintf("type mismatch %T %T", x, y)
+		}
+		for _, key := range sortedKeys(valY) {
+			p := valX.MapIndex(key)
+			if p.IsValid() && contains(p.Interface(), valY.MapIndex(key).Interface(), false) == nil {
+				d.Extra(fmt.Sprintf("[%v]: %v", key, valY.MapIndex(key)))
+			}
+		}
+	default:
+		if eq, _ := Equal(x, y); eq {
+			d.Extra(y)
+		}
+	}
+	if d.Empty() {
+		return true, ""
+	}
+	return false, fmt.Sprintf("%T ⊉ %T unexpected values found\n%s", x, y, d)
+}
+
+// contains checks y is a subset of x, fold compares strings case-insensitively
+func contains(x, y interface{}, fold bool) differ {
 	valX := reflect.ValueOf(x)
 	valY := reflect.ValueOf(y)
 	switch valX.Kind() {
@@ -47,11 +116,15 @@ func contains(x, y interface{}) differ {
 				for i, v := range arr {
 					arrI[i] = v
 				}
-				return isInSlice(reflect.ValueOf(v), arrI...)
+				return isInSlice(fold, reflect.ValueOf(v), arrI...)
 
 			}
 		}
-		if strings.Contains(valX.String(), s) {
+		str, sub := valX.String(), s
+		if fold {
+			str, sub = strings.ToLower(str), strings.ToLower(sub)
+		}
+		if strings.Contains(str, sub) {
 			return nil
 		}
 		return newDiff(x, s)
@@ -63,12 +136,12 @@ func contains(x, y interface{}) differ {
 			for i := 0; i < valY.Len(); i++ {
 				child[i] = valY.Index(i).Interface()
 			}
-			if d := isInSlice(valX, child...); d != nil {
+			if d := isInSlice(fold, valX, child...); d != nil {
 				return newDiffMsg(x, y, d.String())
 			}
 			return nil
 		}
-		if d := isInSlice(valX, y); d != nil {
+		if d := isInSlice(fold, valX, y); d != nil {
 			return newDiffMsg(x, y, d.String())
 		}
 		return nil
@@ -77,7 +150,7 @@ func contains(x, y interface{}) differ {
 			return newMessagef("type mismatch %T %T", x, y)
 
 		}
-		if d := isInMap(valX, valY); d != nil {
+		if d := isInMap(fold, valX, valY); d != nil {
 			return newDiffMsg(x, y, d.String())
 		}
 		return nil
@@ -89,23 +162,39 @@ func contains(x, y interface{}) differ {
 	return newMessagef(s)
 }
 
-func isInMap(parent reflect.Value, child reflect.Value) differ {
+func isInMap(fold bool, parent reflect.Value, child reflect.Value) differ {
 	d := &mapDiff{values: make(map[interface{}][]string, 0)}
 	for _, key := range child.MapKeys() {
 		p := parent.MapIndex(key)
+		if !p.IsValid() && fold {
+			p = foldIndex(parent, key)
+		}
 		if !p.IsValid() {
 			d.values[key] = make([]string, 0)
 			continue
 		}
 		c := child.MapIndex(key)
-		if ok := contains(p.Interface(), c.Interface()); ok != nil {
+		if ok := contains(p.Interface(), c.Interface(), fold); ok != nil {
 			d.values[key] = append(d.values[key], ok.String())
 		}
 	}
 	return d.diffOrNil()
 }
 
-func isInSlice(parent reflect.Value, child ...interface{}) differ {
+// foldIndex finds the value of a string key in m ignoring case
+func foldIndex(m reflect.Value, key reflect.Value) reflect.Value {
+	if key.Kind() != reflect.String {
+		return reflect.Value{}
+	}
+	for _, k := range sortedKeys(m) {
+		if strings.EqualFold(k.String(), key.String()) {
+			return m.MapIndex(k)
+		}
+	}
+	return reflect.Value{}
+}
+
+func isInSlice(fold bool, parent reflect.Value, child ...interface{}) differ {
 	c := &collection{
 		found:   make([]interface{}, 0),
 		missing: make([]interface{}, 0),
@@ -114,7 +203,7 @@ func isInSlice(parent reflect.Value, child ...interface{}) differ {
 		found := false
 		for i := 0; i < parent.Len(); i++ {
 			p := parent.Index(i)
-			if contains(p.Interface(), v) == nil {
+			if contains(p.Interface(), v, fold) == nil {
 				found = true
 				c.found = append(c.found, v)
 				break
@@ -133,30 +222,314 @@ func isInSlice(parent reflect.Value, child ...interface{}) differ {
 // Equal use the cmp.Diff method to check equality and display differences.
 // This method checks all unexpected values
 func Equal(actual, expected interface{}) (bool, string) {
-	/* var opts []cmp.Option
-	t := reflect.TypeOf(actual)
-	if t == nil {
-	} else if t.Kind() == reflect.Struct {
-		opts = append(opts, cmp.AllowUnexported(actual))
-	} else if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
-		opts = append(opts, cmp.AllowUnexported(reflect.ValueOf(actual).Elem().Interface()))
+	return equal(actual, expected)
+}
+
+// EqualOpts creates a CompareFunc like Equal that also applies the cmp options
+// provided, eg a cmp.Comparer or cmp.Transformer for a domain type.
+//
+//	trial.New(fn, cases).Comparer(trial.EqualOpts(cmp.Comparer(bigEqual)))
+func EqualOpts(opts ...cmp.Option) CompareFunc {
+	return func(actual, expected interface{}) (bool, string) {
+		return equal(actual, expected, opts...)
 	}
-	t = reflect.TypeOf(expected)
-	if t == nil {
-	} else if t.Kind() == reflect.Struct {
-		opts = append(opts, cmp.AllowUnexported(expected))
-	} else if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
-		opts = append(opts, cmp.AllowUnexported(reflect.ValueOf(expected).Elem().Interface()))
-	} */
-	opts := allowUnexported(actual)
-
-	r := cmp.Diff(actual, expected, opts...)
+}
+
+// equal compares actual and expected with cmp.Diff including all unexported
+// fields along with any additional options provided.
+// Byte slices and arrays are displayed as a hexdump
+func equal(actual, expected interface{}, opts ...cmp.Option) (bool, string) {
+	r := cmp.Diff(actual, expected, equalOpts(actual, opts...)...)
+	if r == "" {
+		return true, ""
+	}
+	// render differences between byte sequences of the same type as a hexdump
+	if reflect.TypeOf(actual) == reflect.TypeOf(expected) {
+		if a, ok := toBytes(actual); ok {
+			e, _ := toBytes(expected)
+			if eq, s := hexDiff(a, e); !eq {
+				return false, s
+			}
+		}
+	}
+	return false, r
+}
+
+// equalOpts are the cmp options used by Equal along with any additional options
+func equalOpts(actual interface{}, opts ...cmp.Option) []cmp.Option {
+	opts = append(allowUnexported(actual), opts...)
+	return append(opts, ignoreTagged, ignoreFuncChan)
+}
+
+// EqualExported compares actual and expected like Equal but ignores all
+// unexported fields, so only the public API of a struct is compared.
+// Structs with uncomparable unexported members (eg sync.Mutex) can be compared.
+func EqualExported(actual, expected interface{}) (bool, string) {
+	r := cmp.Diff(actual, expected, ignoreUnexported, ignoreTagged, ignoreFuncChan)
 	return r == "", r
 }
 
+// EqualStrict compares actual and expected like Equal and explicitly reports
+// each nil slice or map that was expected to be empty and each empty one
+// expected to be nil. Used to check a function returns a freshly allocated empty value
+func EqualStrict(actual, expected interface{}) (bool, string) {
+	d := NewDiff()
+	seen := make(map[string]bool)
+	nilEmpty := cmp.FilterPath(func(p cmp.Path) bool {
+		vx, vy := p.Last().Values()
+		if !vx.IsValid() || !vy.IsValid() || vx.Type() != vy.Type() {
+			return false
+		}
+		if k := vx.Kind(); k != reflect.Slice && k != reflect.Map {
+			return false
+		}
+		if vx.IsNil() == vy.IsNil() || vx.Len() != 0 || vy.Len() != 0 {
+			return false
+		}
+		s := fmt.Sprintf("%s%s, expected %s", pathName(p), nilOrEmpty(vx), nilOrEmpty(vy))
+		if !seen[s] {
+			seen[s] = true
+			d.Errorf("%s", s)
+		}
+		return false
+	}, cmp.Ignore())
+	// cmp is used directly as byte slices are compared as a hexdump by Equal
+	if r := cmp.Diff(actual, expected, equalOpts(actual, nilEmpty)...); r != "" {
+		return false, r + "\n" + d.String()
+	}
+	return true, ""
+}
+
+// nilOrEmpty describes an empty slice or map as nil or empty
+func nilOrEmpty(v reflect.Value) string {
+	if v.IsNil() {
+		return fmt.Sprintf("nil %v", v.Type())
+	}
+	return fmt.Sprintf("empty %v", v.Type())
+}
+
+// ignoreUnexported ignores all unexported struct fields
+var ignoreUnexported = cmp.FilterPath(func(p cmp.Path) bool {
+	sf, ok := p.Last().(cmp.StructField)
+	if !ok {
+		return false
+	}
+	return p.Index(-2).Type().Field(sf.Index()).PkgPath != ""
+}, cmp.Ignore())
+
+// EqualDistinct compares the distinct elements of two slices or arrays
+// ignoring the order and the number of times an element occurs.
+// Values that are not slices or arrays are compared with Equal
+func EqualDistinct(actual, expected interface{}) (bool, string) {
+	valA, valE := reflect.ValueOf(actual), reflect.ValueOf(expected)
+	if !isList(valA) || !isList(valE) {
+		return Equal(actual, expected)
+	}
+	a, e := distinct(listValues(valA)), distinct(listValues(valE))
+	d := NewDiff()
+	for _, v := range a {
+		if indexOf(e, v) == -1 {
+			d.Extra(v)
+		}
+	}
+	for _, v := range e {
+		if indexOf(a, v) == -1 {
+			d.Missing(v)
+		}
+	}
+	return d.Empty(), d.String()
+}
+
+// EqualIgnoreOrder compares slices or arrays as equal when they have the
+// same elements the same number of times in any order. Nested slices and the
+// values of maps (eg map[string][]int) are also compared ignoring order with the
+// differences reported per key. Other values are compared with Equal
+func EqualIgnoreOrder(actual, expected interface{}) (bool, string) {
+	valA, valE := reflect.ValueOf(actual), reflect.ValueOf(expected)
+	if valA.Kind() == reflect.Map && valE.Kind() == reflect.Map && valA.Type() == valE.Type() {
+		return mapIgnoreOrder(valA, valE)
+	}
+	if !isList(valA) || !isList(valE) {
+		return Equal(actual, expected)
+	}
+	a, e := listValues(valA), listValues(valE)
+	matched := make([]bool, len(e))
+	d := NewDiff()
+	for _, v := range a {
+		found := false
+		for j := range e {
+			if matched[j] {
+				continue
+			}
+			if eq, _ := EqualIgnoreOrder(v, e[j]); eq {
+				matched[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			d.Extra(v)
+		}
+	}
+	for j, v := range e {
+		if !matched[j] {
+			d.Missing(v)
+		}
+	}
+	return d.Empty(), d.String()
+}
+
+// mapIgnoreOrder compares the values of each key of two maps with EqualIgnoreOrder
+func mapIgnoreOrder(actual, expected reflect.Value) (bool, string) {
+	if actual.IsNil() != expected.IsNil() {
+		return Equal(actual.Interface(), expected.Interface())
+	}
+	d := NewDiff()
+	for _, key := range sortedKeys(actual) {
+		if !expected.MapIndex(key).IsValid() {
+			d.Extra(fmt.Sprintf("[%v]: %v", key, actual.MapIndex(key)))
+		}
+	}
+	for _, key := range sortedKeys(expected) {
+		a, e := actual.MapIndex(key), expected.MapIndex(key)
+		if !a.IsValid() {
+			d.Missing(fmt.Sprintf("[%v]: %v", key, e))
+			continue
+		}
+		if eq, diff := EqualIgnoreOrder(a.Interface(), e.Interface()); !eq {
+			d.Errorf("[%v]:\n%s", key, indent(diff))
+		}
+	}
+	return d.Empty(), d.String()
+}
+
+// isList checks if v is a slice or array
+func isList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// listValues converts a slice or array into a []interface{}
+func listValues(v reflect.Value) []interface{} {
+	values := make([]interface{}, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		values[i] = v.Index(i).Interface()
+	}
+	return values
+}
+
+// distinct removes any duplicate values using Equal
+func distinct(values []interface{}) []interface{} {
+	d := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		if indexOf(d, v) == -1 {
+			d = append(d, v)
+		}
+	}
+	return d
+}
+
+// indexOf returns the index of the first value in values that is Equal to v
+// or -1 if not present
+func indexOf(values []interface{}, v interface{}) int {
+	for i, value := range values {
+		if eq, _ := Equal(value, v); eq {
+			return i
+		}
+	}
+	return -1
+}
+
+// EqualIgnore compares actual and expected like Equal ignoring the struct fields
+// at each path. Nested fields are separated by a dot (eg "Meta.CreatedAt").
+// Fields of structs in slices and maps are matched without the index
+// so "ID" ignores the ID field of every element of a []User
+func EqualIgnore(fields ...string) CompareFunc {
+	ignore := cmp.FilterPath(func(p cmp.Path) bool {
+		if _, isField := p.Last().(cmp.StructField); !isField {
+			return false
+		}
+		path := p.String()
+		for _, f := range fields {
+			if path == f {
+				return true
+			}
+		}
+		return false
+	}, cmp.Ignore())
+	return func(actual, expected interface{}) (bool, string) {
+		return equal(actual, expected, ignore)
+	}
+}
+
+// PartialEqual compares actual and expected like Equal but only the struct fields
+// that are set in expected. Zero value fields of expected are ignored at any depth
+// so only the fields of interest need to be populated.
+func PartialEqual(actual, expected interface{}) (bool, string) {
+	return equal(actual, expected, ignoreZeroExpected)
+}
+
+// ignoreZeroExpected ignores struct fields that are the zero value in expected
+var ignoreZeroExpected = cmp.FilterPath(func(p cmp.Path) bool {
+	if _, isField := p.Last().(cmp.StructField); !isField {
+		return false
+	}
+	_, vy := p.Last().Values()
+	return !vy.IsValid() || vy.IsZero()
+}, cmp.Ignore())
+
+// ignoreTagged ignores all struct fields with the tag `trial:"ignore"` at any depth
+var ignoreTagged = cmp.FilterPath(func(p cmp.Path) bool {
+	sf, ok := p.Last().(cmp.StructField)
+	if !ok {
+		return false
+	}
+	parent := p.Index(-2).Type()
+	return parent.Field(sf.Index()).Tag.Get("trial") == "ignore"
+}, cmp.Ignore())
+
+// ignoreFuncChan ignores struct fields that are functions or channels
+// as they can't be meaningfully compared
+var ignoreFuncChan = cmp.FilterPath(func(p cmp.Path) bool {
+	sf, ok := p.Last().(cmp.StructField)
+	if !ok {
+		return false
+	}
+	k := sf.Type().Kind()
+	return k == reflect.Func || k == reflect.Chan
+}, cmp.Ignore())
+
 // allowUnexported sets up i to be compared including unexported fields using cmp.Diff or cmp.Equal.
 // this function includes all unexported embedded structs or pointers to structs at all depths
 func allowUnexported(i interface{}) []cmp.Option {
+	return allowUnexportedTypes(i, &visited{
+		types: make(map[reflect.Type]bool),
+		ptrs:  make(map[uintptr]bool),
+	})
+}
+
+// visited tracks the struct types whose options have been gathered
+// and the pointers that have been walked
+type visited struct {
+	types map[reflect.Type]bool
+	ptrs  map[uintptr]bool
+}
+
+// element returns the interface of a slice or map element to gather its options.
+// A nil pointer to a struct is replaced with the struct's zero value
+// and any other nil element is skipped
+func element(v reflect.Value) interface{} {
+	if !canBeNil(v.Type()) || !v.IsNil() {
+		return v.Interface()
+	}
+	if v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct {
+		return reflect.New(v.Type().Elem()).Elem().Interface()
+	}
+	return nil
+}
+
+// allowUnexportedTypes gathers the options of each struct type only once.
+// The values of every struct are still walked so the dynamic types of exported interface fields are found,
+// unexported fields are walked as zero values. Visited pointers prevent endless recursion on self-referential values
+func allowUnexportedTypes(i interface{}, seen *visited) []cmp.Option {
 	opts := make([]cmp.Option, 0)
 	t := reflect.TypeOf(i)
 	// skip invalid types
@@ -169,23 +542,35 @@ func allowUnexported(i interface{}) []cmp.Option {
 		if t.Elem().Kind() != reflect.Struct {
 			return opts
 		}
-		if reflect.ValueOf(i).IsNil() {
+		p := reflect.ValueOf(i)
+		if p.IsNil() || seen.ptrs[p.Pointer()] {
 			return opts
 		}
-		i = reflect.ValueOf(i).Elem().Interface()
+		seen.ptrs[p.Pointer()] = true
+		i = p.Elem().Interface()
 		fallthrough
 	case reflect.Struct:
-		opts = append(opts, cmp.AllowUnexported(i))
+		if !seen.types[reflect.TypeOf(i)] {
+			seen.types[reflect.TypeOf(i)] = true
+			opts = append(opts, cmp.AllowUnexported(i))
+		}
 		rStruct := reflect.ValueOf(i)
 
 		// look through all fields of a struct for embedded structs
 		for index := 0; index < rStruct.NumField(); index++ {
 			v := rStruct.Field(index)
 			if v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct {
+				if v.CanInterface() {
+					opts = append(opts, allowUnexportedTypes(v.Interface(), seen)...)
+					continue
+				}
 				// to support unexported (private) fields we need to create a copy
 				// of the field and then dereference the pointer to that struct
+				if seen.types[v.Elem().Type()] {
+					continue
+				}
 				i = reflect.New(v.Elem().Type()).Elem().Interface()
-				opts = append(opts, allowUnexported(i)...)
+				opts = append(opts, allowUnexportedTypes(i, seen)...)
 				continue
 			}
 			if !v.CanInterface() {
@@ -193,21 +578,19 @@ func allowUnexported(i interface{}) []cmp.Option {
 				// get the interface{} so instead create a copy of that field
 				v = reflect.New(v.Type()).Elem()
 			}
-			opts = append(opts, allowUnexported(v.Interface())...)
+			opts = append(opts, allowUnexportedTypes(v.Interface(), seen)...)
 		}
 	case reflect.Map:
 		m := reflect.ValueOf(i)
 		for _, key := range m.MapKeys() {
-			v := m.MapIndex(key)
-			opts = append(opts, allowUnexported(v.Interface())...)
+			opts = append(opts, allowUnexportedTypes(element(m.MapIndex(key)), seen)...)
 		}
 	case reflect.Array:
 		fallthrough
 	case reflect.Slice:
 		s := reflect.ValueOf(i)
 		for i := 0; i < s.Len(); i++ {
-			v := s.Index(i)
-			opts = append(opts, allowUnexported(v.Interface())...)
+			opts = append(opts, allowUnexportedTypes(element(s.Index(i)), seen)...)
 		}
 	default:
 		return opts
@@ -279,7 +662,7 @@ func newDiff(x, y interface{}) *diff {
 	return &diff{
 		x:   x,
 		y:   y,
-		msg: fmt.Sprintf(" + %v\n - %v", x, y)}
+		msg: NewDiff().Extra(x).Missing(y).String()}
 }
 
 func newDiffMsg(x, y interface{}, s string) *diff {
@@ -2,8 +2,13 @@ package trial
 
 import (
 	"errors"
+	"math/big"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestEqualFn(t *testing.T) {
@@ -109,6 +114,167 @@ func TestEqualFn(t *testing.T) {
 	New(fn, cases).Test(t)
 }
 
+func TestEqual_IgnoreTag(t *testing.T) {
+	type record struct {
+		Name    string
+		Created time.Time `trial:"ignore"`
+		id      int       `trial:"ignore"`
+	}
+	type parent struct {
+		Record  record
+		Records []*record
+		count   int
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		r, _ := Equal(args[0], args[1])
+		return r, nil
+	}
+	New(fn, Cases{
+		"ignore tagged fields": {
+			Input:    Args(record{Name: "a", Created: time.Now(), id: 1}, record{Name: "a"}),
+			Expected: true,
+		},
+		"non tagged fields are compared": {
+			Input:    Args(record{Name: "a", id: 1}, record{Name: "b", id: 1}),
+			Expected: false,
+		},
+		"nested tagged fields": {
+			Input: Args(
+				parent{Record: record{Name: "a", id: 1}, Records: []*record{{Name: "b", Created: time.Now()}}},
+				parent{Record: record{Name: "a", id: 2}, Records: []*record{{Name: "b"}}},
+			),
+			Expected: true,
+		},
+		"untagged parent field": {
+			Input:    Args(parent{count: 1}, parent{count: 2}),
+			Expected: false,
+		},
+	}).Test(t)
+}
+
+func TestEqual_FuncChanFields(t *testing.T) {
+	type service struct {
+		Name     string
+		OnChange func()
+		done     chan int
+		callback func(int) error
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		r, _ := Equal(args[0], args[1])
+		return r, nil
+	}
+	New(fn, Cases{
+		"ignore func and chan fields": {
+			Input: Args(
+				service{Name: "a", OnChange: func() {}, done: make(chan int), callback: func(int) error { return nil }},
+				service{Name: "a"},
+			),
+			Expected: true,
+		},
+		"pointer to struct": {
+			Input:    Args(&service{Name: "a", done: make(chan int)}, &service{Name: "a", done: make(chan int)}),
+			Expected: true,
+		},
+		"compare data fields": {
+			Input:    Args(service{Name: "a", OnChange: func() {}}, service{Name: "b", OnChange: func() {}}),
+			Expected: false,
+		},
+	}).Test(t)
+}
+
+func TestNotContains(t *testing.T) {
+	New(func(args ...interface{}) (interface{}, error) {
+		b, s := NotContains(args[0], args[1])
+		var err error
+		if s != "" {
+			err = errors.New(s)
+		}
+		return b, err
+	}, Cases{
+		"substring absent": {
+			Input:    Args("Hello World", "hello"),
+			Expected: true,
+		},
+		"substring found": {
+			Input:       Args("Hello World", "World"),
+			ExpectedErr: errors.New("string ⊉ string unexpected values found\n + World"),
+		},
+		"element absent": {
+			Input:    Args([]int{1, 2, 3}, 4),
+			Expected: true,
+		},
+		"elements found": {
+			Input:       Args([]int{1, 2, 3}, []int{4, 3, 1}),
+			ExpectedErr: errors.New("[]int ⊉ []int unexpected values found\n + 3\n + 1"),
+		},
+		"key value absent": {
+			Input:    Args(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 2, "c": 1}),
+			Expected: true,
+		},
+		"key value found": {
+			Input:       Args(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 2, "b": 2}),
+			ExpectedErr: errors.New(" + [b]: 2"),
+		},
+		"type mismatch": {
+			Input:       Args(map[string]int{"a": 1}, "a"),
+			ExpectedErr: errors.New("type mismatch map[string]int string"),
+		},
+		"nothing expected": {
+			Input:    Args("abc", nil),
+			Expected: true,
+		},
+	}).Test(t)
+}
+
+func TestContainsInsensitive(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		b, s := ContainsInsensitive(args[0], args[1])
+		var err error
+		if s != "" {
+			err = errors.New(s)
+		}
+		return b, err
+	}
+	New(fn, Cases{
+		"different case": {
+			Input:    Args("Hello World", "hello WORLD"),
+			Expected: true,
+		},
+		"original case in diff": {
+			Input:       Args("Hello World", "Goodbye"),
+			ExpectedErr: errors.New("string ⊇ string\n + Hello World\n - Goodbye"),
+		},
+		"string with []string": {
+			Input:    Args("The Quick Brown Fox", []string{"quick", "FOX"}),
+			Expected: true,
+		},
+		"slice of strings": {
+			Input:    Args([]string{"INFO started", "WARN retry"}, []string{"info", "warn"}),
+			Expected: true,
+		},
+		"string keyed map": {
+			Input:    Args(map[string]string{"Level": "ERROR"}, map[string]string{"level": "error"}),
+			Expected: true,
+		},
+		"map missing key": {
+			Input:       Args(map[string]string{"Level": "ERROR"}, map[string]string{"msg": "error"}),
+			ExpectedErr: errors.New("msg"),
+		},
+		"non string": {
+			Input:    Args([]int{1, 2, 3}, 2),
+			Expected: true,
+		},
+		"non string mismatch": {
+			Input:       Args(map[int]int{1: 1}, 1),
+			ExpectedErr: errors.New("type mismatch map[int]int int"),
+		},
+		"nil": {
+			Input:    Args("a", nil),
+			Expected: true,
+		},
+	}).Test(t)
+}
+
 func TestContainsFn(t *testing.T) {
 	New(func(args ...interface{}) (interface{}, error) {
 		b, s := ContainsFn(args[0], args[1])
@@ -245,6 +411,417 @@ func TestContainsFn(t *testing.T) {
 	}).SubTest(t)
 }
 
+func TestEqualDistinct(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		b, s := EqualDistinct(args[0], args[1])
+		var err error
+		if s != "" {
+			err = errors.New(s)
+		}
+		return b, err
+	}
+	New(fn, Cases{
+		"same order": {
+			Input:    Args([]int{1, 2, 3}, []int{1, 2, 3}),
+			Expected: true,
+		},
+		"different order": {
+			Input:    Args([]int{3, 1, 2}, []int{1, 2, 3}),
+			Expected: true,
+		},
+		"ignore duplicates": {
+			Input:    Args([]string{"a", "a", "b"}, []string{"b", "a", "b"}),
+			Expected: true,
+		},
+		"array and slice": {
+			Input:    Args([3]int{1, 1, 2}, []int{2, 1}),
+			Expected: true,
+		},
+		"extra value": {
+			Input:       Args([]int{1, 2, 3}, []int{1, 2}),
+			ExpectedErr: errors.New(" + 3"),
+		},
+		"missing value": {
+			Input:       Args([]int{1, 1}, []int{1, 2}),
+			ExpectedErr: errors.New(" - 2"),
+		},
+		"non slice values": {
+			Input:    Args(1, 1),
+			Expected: true,
+		},
+	}).Test(t)
+}
+
+func TestEqual_SelfReferential(t *testing.T) {
+	type node struct {
+		Value    int
+		Next     *node
+		Children []*node
+		parent   *node
+	}
+	list := func(values ...int) *node {
+		head := &node{Value: values[0]}
+		head.Children = []*node{head}
+		cur := head
+		for _, v := range values[1:] {
+			cur.Next = &node{Value: v, parent: cur, Children: []*node{head}}
+			cur = cur.Next
+		}
+		return head
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, _ := Equal(args[0], args[1])
+		return eq, nil
+	}
+	New(fn, Cases{
+		"same list": {
+			Input:    Args(list(1, 2, 3), list(1, 2, 3)),
+			Expected: true,
+		},
+		"different list": {
+			Input:    Args(list(1, 2, 3), list(1, 2, 4)),
+			Expected: false,
+		},
+	}).Test(t)
+}
+
+func TestEqual_NilElements(t *testing.T) {
+	type item struct {
+		Name  string
+		price int
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, _ := Equal(args[0], args[1])
+		return eq, nil
+	}
+	New(fn, Cases{
+		"slice with nils": {
+			Input:    Args([]*item{nil, {Name: "a", price: 1}, nil}, []*item{nil, {Name: "a", price: 1}, nil}),
+			Expected: true,
+		},
+		"only nils": {
+			Input:    Args([]*item{nil}, []*item{{price: 1}}),
+			Expected: false,
+		},
+		"map with nils": {
+			Input:    Args(map[string]*item{"a": nil, "b": {price: 2}}, map[string]*item{"a": nil, "b": {price: 2}}),
+			Expected: true,
+		},
+		"map nil differs": {
+			Input:    Args(map[string]*item{"a": nil}, map[string]*item{"a": {price: 2}}),
+			Expected: false,
+		},
+		"nil interface elements": {
+			Input:    Args([]interface{}{nil, &item{price: 1}}, []interface{}{nil, &item{price: 1}}),
+			Expected: true,
+		},
+		"nil slices in map": {
+			Input:    Args(map[string][]*item{"a": nil}, map[string][]*item{"a": nil}),
+			Expected: true,
+		},
+	}).Test(t)
+}
+
+func TestEqualStrict(t *testing.T) {
+	type result struct {
+		IDs   []int
+		Names map[string]int
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, diff := EqualStrict(args[0], args[1])
+		if !eq {
+			return nil, errors.New(diff)
+		}
+		return eq, nil
+	}
+	New(fn, Cases{
+		"nil expected empty": {
+			Input:       Args([]int(nil), []int{}),
+			ExpectedErr: errors.New("nil []int, expected empty []int"),
+		},
+		"empty expected nil": {
+			Input:       Args(map[string]int{}, map[string]int(nil)),
+			ExpectedErr: errors.New("empty map[string]int, expected nil map[string]int"),
+		},
+		"nested fields": {
+			Input:       Args(result{IDs: []int{}}, result{Names: map[string]int{}}),
+			ExpectedErr: errors.New("IDs: empty []int, expected nil []int\nNames: nil map[string]int, expected empty map[string]int"),
+		},
+		"bytes": {
+			Input:       Args([]byte(nil), []byte{}),
+			ExpectedErr: errors.New("nil []uint8, expected empty []uint8"),
+		},
+		"both empty": {
+			Input:    Args(result{IDs: []int{}, Names: map[string]int{}}, result{IDs: []int{}, Names: map[string]int{}}),
+			Expected: true,
+		},
+		"both nil": {
+			Input:    Args(result{}, result{}),
+			Expected: true,
+		},
+		"other differences": {
+			Input:       Args([]int{1}, []int{2}),
+			ExpectedErr: errors.New("[]int{"),
+		},
+	}).Test(t)
+}
+
+type zin struct{ v int }
+type zmid struct{ I interface{} }
+type zout struct{ P *zmid }
+
+func TestEqual_InterfaceFields(t *testing.T) {
+	type a struct{ v int }
+	type b struct{ v int }
+	type w struct{ V interface{} }
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, _ := Equal(args[0], args[1])
+		return eq, nil
+	}
+	New(fn, Cases{
+		"same values": {
+			Input:    Args([]w{{V: a{1}}, {V: b{1}}}, []w{{V: a{1}}, {V: b{1}}}),
+			Expected: true,
+		},
+		"interface behind a pointer": {
+			Input:    Args(zout{P: &zmid{I: zin{1}}}, zout{P: &zmid{I: zin{2}}}),
+			Expected: false,
+		},
+		"different type in later element": {
+			Input:    Args([]w{{V: a{1}}, {V: b{1}}}, []w{{V: a{1}}, {V: b{2}}}),
+			Expected: false,
+		},
+	}).Test(t)
+}
+
+func TestEqualOpts(t *testing.T) {
+	type account struct {
+		Name    string
+		Balance *big.Int
+		id      int
+	}
+	bigEqual := cmp.Comparer(func(x, y *big.Int) bool { return x.Cmp(y) == 0 })
+	lower := cmp.Transformer("lower", strings.ToLower)
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, _ := EqualOpts(bigEqual, lower)(args[0], args[1])
+		return eq, nil
+	}
+	New(fn, Cases{
+		"custom comparer": {
+			Input:    Args(account{Balance: big.NewInt(10)}, account{Balance: new(big.Int).SetInt64(10)}),
+			Expected: true,
+		},
+		"custom comparer differs": {
+			Input:    Args(account{Balance: big.NewInt(10)}, account{Balance: big.NewInt(11)}),
+			Expected: false,
+		},
+		"transformer": {
+			Input:    Args(account{Name: "Bob", Balance: big.NewInt(1)}, account{Name: "bob", Balance: big.NewInt(1)}),
+			Expected: true,
+		},
+		"unexported still compared": {
+			Input:    Args(account{Balance: big.NewInt(1), id: 1}, account{Balance: big.NewInt(1), id: 2}),
+			Expected: false,
+		},
+	}).Test(t)
+
+	if eq, _ := EqualOpts()(1, 1); !eq {
+		t.Error("FAIL: EqualOpts without options should compare like Equal")
+	}
+}
+
+func TestEqualExported(t *testing.T) {
+	type inner struct {
+		Value  int
+		hidden string
+	}
+	type guarded struct {
+		Name  string
+		Inner *inner
+		mu    sync.Mutex
+		cache map[string]int
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, _ := EqualExported(args[0], args[1])
+		return eq, nil
+	}
+	New(fn, Cases{
+		"unexported differ": {
+			Input:    Args(&guarded{Name: "a", cache: map[string]int{"x": 1}}, &guarded{Name: "a"}),
+			Expected: true,
+		},
+		"exported differ": {
+			Input:    Args(&guarded{Name: "a"}, &guarded{Name: "b"}),
+			Expected: false,
+		},
+		"nested unexported": {
+			Input:    Args(&guarded{Inner: &inner{Value: 1, hidden: "x"}}, &guarded{Inner: &inner{Value: 1}}),
+			Expected: true,
+		},
+		"nested exported": {
+			Input:    Args(&guarded{Inner: &inner{Value: 1}}, &guarded{Inner: &inner{Value: 2}}),
+			Expected: false,
+		},
+		"non struct": {
+			Input:    Args([]int{1, 2}, []int{1, 2}),
+			Expected: true,
+		},
+	}).Test(t)
+}
+
+func TestEqualIgnore(t *testing.T) {
+	type meta struct {
+		ID        int
+		CreatedAt time.Time
+	}
+	type record struct {
+		ID    int
+		Name  string
+		Meta  meta
+		Items []meta
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, _ := EqualIgnore("ID", "Meta.CreatedAt", "Items.ID")(args[0], args[1])
+		return eq, nil
+	}
+	now := time.Now()
+	New(fn, Cases{
+		"ignored fields differ": {
+			Input: Args(
+				record{ID: 1, Name: "a", Meta: meta{ID: 5, CreatedAt: now}, Items: []meta{{ID: 1}}},
+				record{ID: 2, Name: "a", Meta: meta{ID: 5}, Items: []meta{{ID: 2}}},
+			),
+			Expected: true,
+		},
+		"nested field with same name is not ignored": {
+			Input:    Args(record{Meta: meta{ID: 1}}, record{Meta: meta{ID: 2}}),
+			Expected: false,
+		},
+		"other field differs": {
+			Input:    Args(record{ID: 1, Name: "a"}, record{ID: 2, Name: "b"}),
+			Expected: false,
+		},
+		"slice of structs": {
+			Input:    Args([]record{{ID: 1, Name: "a"}}, []record{{ID: 2, Name: "a"}}),
+			Expected: true,
+		},
+		"unignored nested time": {
+			Input:    Args(record{Items: []meta{{CreatedAt: now}}}, record{Items: []meta{{}}}),
+			Expected: false,
+		},
+	}).Test(t)
+}
+
+func TestPartialEqual(t *testing.T) {
+	type address struct {
+		City string
+		Zip  string
+	}
+	type user struct {
+		ID      int
+		Name    string
+		Tags    []string
+		Address address
+		Manager *user
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, _ := PartialEqual(args[0], args[1])
+		return eq, nil
+	}
+	full := user{ID: 1, Name: "bob", Tags: []string{"a"}, Address: address{City: "NYC", Zip: "10001"}, Manager: &user{Name: "alice"}}
+	New(fn, Cases{
+		"only set fields": {
+			Input:    Args(full, user{Name: "bob"}),
+			Expected: true,
+		},
+		"set field differs": {
+			Input:    Args(full, user{Name: "tom"}),
+			Expected: false,
+		},
+		"nested partial struct": {
+			Input:    Args(full, user{Address: address{City: "NYC"}}),
+			Expected: true,
+		},
+		"nested field differs": {
+			Input:    Args(full, user{Address: address{Zip: "20002"}}),
+			Expected: false,
+		},
+		"pointer to partial struct": {
+			Input:    Args(full, user{Manager: &user{Name: "alice"}}),
+			Expected: true,
+		},
+		"slice field": {
+			Input:    Args(full, user{Tags: []string{"b"}}),
+			Expected: false,
+		},
+		"empty expected": {
+			Input:    Args(full, user{}),
+			Expected: true,
+		},
+	}).Test(t)
+}
+
+func TestEqualIgnoreOrder(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		b, s := EqualIgnoreOrder(args[0], args[1])
+		var err error
+		if s != "" {
+			err = errors.New(s)
+		}
+		return b, err
+	}
+	New(fn, Cases{
+		"different order": {
+			Input:    Args([]int{3, 1, 2}, []int{1, 2, 3}),
+			Expected: true,
+		},
+		"duplicates counted": {
+			Input:       Args([]string{"a", "a", "b"}, []string{"a", "b", "b"}),
+			ExpectedErr: errors.New(" - b"),
+		},
+		"extra duplicate": {
+			Input:       Args([]string{"a", "a", "b"}, []string{"a", "b"}),
+			ExpectedErr: errors.New(" + a"),
+		},
+		"nested slices": {
+			Input:    Args([][]int{{1, 2}, {3}}, [][]int{{3}, {2, 1}}),
+			Expected: true,
+		},
+		"nested slice differs": {
+			Input:       Args([][]int{{1, 2}}, [][]int{{1, 3}}),
+			ExpectedErr: errors.New(" + [1 2]\n - [1 3]"),
+		},
+		"structs": {
+			Input:    Args([]point{{X: 1}, {Y: 2}}, []point{{Y: 2}, {X: 1}}),
+			Expected: true,
+		},
+		"non slice values": {
+			Input:    Args("a", "a"),
+			Expected: true,
+		},
+		"map of slices": {
+			Input:    Args(map[string][]int{"a": {2, 1}, "b": {3}}, map[string][]int{"a": {1, 2}, "b": {3}}),
+			Expected: true,
+		},
+		"map value differs": {
+			Input:       Args(map[string][]int{"a": {2, 1}, "b": {3, 4}}, map[string][]int{"a": {1, 2}, "b": {3, 5}}),
+			ExpectedErr: errors.New("[b]:\n   + 4\n   - 5"),
+		},
+		"map keys differ": {
+			Input:       Args(map[string][]int{"a": {1}, "c": {3}}, map[string][]int{"a": {1}, "b": {2}}),
+			ExpectedErr: errors.New(" + [c]: [3]\n - [b]: [2]"),
+		},
+		"slice of maps": {
+			Input:    Args([]map[int][]string{{1: {"y", "x"}}}, []map[int][]string{{1: {"x", "y"}}}),
+			Expected: true,
+		},
+		"nil map": {
+			Input:     Args(map[string][]int(nil), map[string][]int{}),
+			ShouldErr: true,
+		},
+	}).Test(t)
+}
+
 func TestCmpFuncs(t *testing.T) {
 	fn := func(args ...interface{}) (interface{}, error) {
 		_, s := CmpFuncs(args[0], args[1])
@@ -1,5 +1,5 @@
 module github.com/jbsmith7741/trial
 
-go 1.14
+go 1.18
 
 require github.com/google/go-cmp v0.4.1
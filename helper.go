@@ -1,6 +1,8 @@
 package trial
 
 import (
+	"fmt"
+	"reflect"
 	"time"
 )
 
@@ -10,6 +12,131 @@ func Args(args ...interface{}) interface{} {
 	return args
 }
 
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Func creates a TestFunc that calls fn with the Input of each case so the method
+// being tested doesn't need a wrapper. The number and types of the args are checked
+// against fn's parameters before it's called and a mismatch fails the case naming the problem,
+// even when the case expects an error. fn may return nothing, a result, an error or a result and an error.
+//
+//	trial.New(trial.Func(strings.Repeat), cases)
+func Func(fn interface{}) TestFunc {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		panic(fmt.Sprintf("trial.Func: %T is not a func", fn))
+	}
+	typ := v.Type()
+	if typ.NumOut() > 2 || (typ.NumOut() == 2 && typ.Out(1) != errorType) {
+		panic(fmt.Sprintf("trial.Func: %v must return (result, error)", typ))
+	}
+	return func(args ...interface{}) (interface{}, error) {
+		in, err := funcArgs(typ, args)
+		if err != nil {
+			return nil, err
+		}
+		return funcResult(v.Call(in))
+	}
+}
+
+// argsError is returned by a Func when the args don't match its parameters.
+// It always fails the case so it can't be matched by ShouldErr or ExpectedErr
+type argsError string
+
+func (e argsError) Error() string {
+	return string(e)
+}
+
+func argsErrorf(format string, args ...interface{}) error {
+	return argsError(fmt.Sprintf(format, args...))
+}
+
+// funcArgs checks args match the parameters of typ and converts them to values
+func funcArgs(typ reflect.Type, args []interface{}) ([]reflect.Value, error) {
+	n := typ.NumIn()
+	if typ.IsVariadic() && len(args) < n-1 {
+		return nil, argsErrorf("%v expects at least %d args, got %d", typ, n-1, len(args))
+	} else if !typ.IsVariadic() && len(args) != n {
+		return nil, argsErrorf("%v expects %d args, got %d", typ, n, len(args))
+	}
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var p reflect.Type
+		if typ.IsVariadic() && i >= n-1 {
+			p = typ.In(n - 1).Elem()
+		} else {
+			p = typ.In(i)
+		}
+		if arg == nil && canBeNil(p) {
+			in[i] = reflect.Zero(p)
+			continue
+		} else if arg == nil {
+			return nil, argsErrorf("%v arg %d is nil, expected %v", typ, i, p)
+		}
+		a := reflect.ValueOf(arg)
+		if !a.Type().AssignableTo(p) {
+			return nil, argsErrorf("%v arg %d is %T, expected %v", typ, i, arg, p)
+		}
+		in[i] = a
+	}
+	return in, nil
+}
+
+// funcResult converts the values returned by a Func to a result and error
+func funcResult(out []reflect.Value) (result interface{}, err error) {
+	if len(out) > 0 && out[len(out)-1].Type() == errorType {
+		err, _ = out[len(out)-1].Interface().(error)
+		out = out[:len(out)-1]
+	}
+	if len(out) == 1 {
+		result = out[0].Interface()
+	}
+	return result, err
+}
+
+// StringParseRoundTrip creates a TestFunc that checks Input survives a
+// round trip through its String method and the parse func, parse(x.String()) == x.
+// The parsed value is returned as the result and an error describing
+// the differences is returned if the round trip diverges.
+func StringParseRoundTrip(parse func(string) (interface{}, error)) TestFunc {
+	return func(args ...interface{}) (interface{}, error) {
+		x, ok := args[0].(fmt.Stringer)
+		if !ok {
+			return nil, fmt.Errorf("%T does not implement fmt.Stringer", args[0])
+		}
+		s := x.String()
+		v, err := parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("parse %q: %v", s, err)
+		}
+		if equal, diff := Equal(v, x); !equal {
+			return v, fmt.Errorf("round trip of %q differs\n%s", s, diff)
+		}
+		return v, nil
+	}
+}
+
+// Pipeline creates a TestFunc that chains fns together, each result is
+// passed as the input to the next func. Results created with Args are passed as
+// multiple arguments. The pipeline stops at the first error and reports
+// the stage that failed along with its input.
+func Pipeline(fns ...TestFunc) TestFunc {
+	return func(args ...interface{}) (interface{}, error) {
+		var result interface{} = args
+		for i, fn := range fns {
+			input := result
+			inputs, ok := input.([]interface{})
+			if !ok {
+				inputs = []interface{}{input}
+			}
+			var err error
+			if result, err = fn(inputs...); err != nil {
+				return result, fmt.Errorf("stage %d with input %v: %w", i, input, err)
+			}
+		}
+		return result, nil
+	}
+}
+
 // IntP returns a pointer to a defined int
 func IntP(i int) *int {
 	return &i
@@ -0,0 +1,143 @@
+package trial
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type level int
+
+func (l level) String() string { return "L" + strconv.Itoa(int(l)) }
+
+func TestStringParseRoundTrip(t *testing.T) {
+	parse := func(s string) (interface{}, error) {
+		i, err := strconv.Atoi(s[1:])
+		if i > 9 {
+			// lossy parse to verify divergence is reported
+			i = 9
+		}
+		return level(i), err
+	}
+	New(StringParseRoundTrip(parse), Cases{
+		"round trip": {
+			Input:    level(3),
+			Expected: level(3),
+		},
+		"round trip diverges": {
+			Input:       level(12),
+			ExpectedErr: errors.New(`round trip of "L12" differs`),
+		},
+		"not a stringer": {
+			Input:       12,
+			ExpectedErr: errors.New("int does not implement fmt.Stringer"),
+		},
+	}).Test(t)
+}
+
+func TestPipeline(t *testing.T) {
+	trim := func(args ...interface{}) (interface{}, error) {
+		return strings.TrimSpace(args[0].(string)), nil
+	}
+	atoi := func(args ...interface{}) (interface{}, error) {
+		return strconv.Atoi(args[0].(string))
+	}
+	split := func(args ...interface{}) (interface{}, error) {
+		i := args[0].(int)
+		return Args(i/10, i%10), nil
+	}
+	sum := func(args ...interface{}) (interface{}, error) {
+		return args[0].(int) + args[1].(int), nil
+	}
+	New(Pipeline(trim, atoi, split, sum), Cases{
+		"all stages": {
+			Input:    " 42 ",
+			Expected: 6,
+		},
+		"stage error": {
+			Input:       " 4x ",
+			ExpectedErr: errors.New(`stage 1 with input 4x: strconv.Atoi: parsing "4x": invalid syntax`),
+		},
+	}).Test(t)
+}
+
+func TestFuncArgs(t *testing.T) {
+	New(Func(strings.Repeat), Cases{
+		"args": {
+			Input:    Args("ab", 2),
+			Expected: "abab",
+		},
+	}).Test(t)
+
+	New(Func(strconv.Atoi), Cases{
+		"result and error": {
+			Input:    "12",
+			Expected: 12,
+		},
+		"error": {
+			Input:     "a",
+			ShouldErr: true,
+		},
+	}).Test(t)
+
+	join := func(sep string, s ...string) string { return strings.Join(s, sep) }
+	New(Func(join), Cases{
+		"variadic": {
+			Input:    Args(",", "a", "b"),
+			Expected: "a,b",
+		},
+		"variadic empty": {
+			Input:    ",",
+			Expected: "",
+		},
+	}).Test(t)
+
+	New(Func(func(m map[string]int) error { return nil }), Cases{
+		"nil map": {Input: Args(nil)},
+	}).Test(t)
+
+	// mismatched args fail the case even when an error is expected
+	cases := map[string]struct {
+		fn      TestFunc
+		Case    Case
+		message string
+	}{
+		"too few args": {
+			fn:      Func(strings.Repeat),
+			Case:    Case{Input: "ab", Expected: "ab"},
+			message: `FAIL: "too few args" func(string, int) string expects 2 args, got 1`,
+		},
+		"too many args": {
+			fn:      Func(strings.Repeat),
+			Case:    Case{Input: Args("ab", 2, 3), ShouldErr: true},
+			message: `FAIL: "too many args" func(string, int) string expects 2 args, got 3`,
+		},
+		"wrong type": {
+			fn:      Func(strings.Repeat),
+			Case:    Case{Input: Args("ab", "2"), ExpectedErr: errors.New("expected int")},
+			message: `FAIL: "wrong type" func(string, int) string arg 1 is string, expected int`,
+		},
+		"nil value": {
+			fn:      Func(strings.Repeat),
+			Case:    Case{Input: Args(nil, 2), ShouldErr: true},
+			message: `FAIL: "nil value" func(string, int) string arg 0 is nil, expected string`,
+		},
+		"variadic too few": {
+			fn:      Func(join),
+			Case:    Case{Input: Args(), ShouldErr: true},
+			message: `FAIL: "variadic too few" func(string, ...string) string expects at least 1 args, got 0`,
+		},
+		"in a pipeline": {
+			fn:      Pipeline(Func(strings.Repeat)),
+			Case:    Case{Input: "ab", ShouldErr: true},
+			message: `FAIL: "in a pipeline" stage 0 with input [ab]: func(string, int) string expects 2 args, got 1`,
+		},
+	}
+	for msg, test := range cases {
+		r := New(test.fn, nil).testCase(msg, test.Case)
+		if eq, diff := Equal(r, result{false, test.message}); !eq {
+			t.Errorf("FAIL: %q %s", msg, diff)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package trial
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// hexRowSize is the number of bytes shown per row of a hex diff
+const hexRowSize = 8
+
+// toBytes converts byte slices and arrays to a []byte
+func toBytes(i interface{}) ([]byte, bool) {
+	v := reflect.ValueOf(i)
+	if !isList(v) || v.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	b := make([]byte, v.Len())
+	for i := range b {
+		b[i] = byte(v.Index(i).Uint())
+	}
+	return b, true
+}
+
+// hexDiff compares two byte sequences and displays any differences as a
+// side by side hexdump. Differing bytes are marked with a *
+func hexDiff(actual, expected []byte) (bool, string) {
+	if bytes.Equal(actual, expected) {
+		return true, ""
+	}
+	offset := 0
+	for offset < len(actual) && offset < len(expected) && actual[offset] == expected[offset] {
+		offset++
+	}
+	s := fmt.Sprintf("bytes differ at offset %d (0x%x): len %d, expected %d\n", offset, offset, len(actual), len(expected))
+	s += fmt.Sprintf("%-8s  %-*s | %s\n", "offset", hexRowSize*3, "actual", "expected")
+
+	size := len(actual)
+	if len(expected) > size {
+		size = len(expected)
+	}
+	lastRow := -hexRowSize
+	for row := 0; row < size; row += hexRowSize {
+		// only show rows with differences and the rows next to them
+		if !rowDiffers(actual, expected, row-hexRowSize, row+2*hexRowSize) {
+			continue
+		}
+		if row-lastRow > hexRowSize {
+			s += "...\n"
+		}
+		lastRow = row
+		ln := fmt.Sprintf("%08x  %s | %s", row, hexRow(actual, expected, row), hexRow(expected, actual, row))
+		s += strings.TrimRight(ln, " ") + "\n"
+	}
+	return false, strings.TrimRight(s, "\n")
+}
+
+// rowDiffers checks if there are any differences between the start and end offset
+func rowDiffers(a, b []byte, start, end int) bool {
+	if start < 0 {
+		start = 0
+	}
+	for i := start; i < end; i++ {
+		if (i < len(a)) != (i < len(b)) {
+			return true
+		}
+		if i < len(a) && a[i] != b[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// hexRow formats a row of b, marking bytes that differ from other
+func hexRow(b, other []byte, row int) string {
+	var s string
+	for i := row; i < row+hexRowSize; i++ {
+		switch {
+		case i >= len(b):
+			s += "   "
+		case i >= len(other) || b[i] != other[i]:
+			s += fmt.Sprintf("*%02x", b[i])
+		default:
+			s += fmt.Sprintf(" %02x", b[i])
+		}
+	}
+	return s
+}
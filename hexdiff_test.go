@@ -0,0 +1,63 @@
+package trial
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHexDiff(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		_, s := Equal(args[0], args[1])
+		return s, nil
+	}
+	New(fn, Cases{
+		"equal bytes": {
+			Input:    Args([]byte("hello"), []byte("hello")),
+			Expected: "",
+		},
+		"offset of first difference": {
+			Input:    Args([]byte("hello world"), []byte("hello World")),
+			Expected: "bytes differ at offset 6 (0x6): len 11, expected 11",
+		},
+		"differing byte is marked": {
+			Input:    Args([]byte{0x01, 0x02, 0x03}, []byte{0x01, 0xff, 0x03}),
+			Expected: "00000000   01*02 03",
+		},
+		"different lengths": {
+			Input:    Args([]byte{0x01}, []byte{0x01, 0x02}),
+			Expected: "bytes differ at offset 1 (0x1): len 1, expected 2",
+		},
+		"arrays": {
+			Input:    Args([4]byte{1, 2, 3, 4}, [4]byte{1, 2, 3, 5}),
+			Expected: "bytes differ at offset 3 (0x3)",
+		},
+		"skip equal rows": {
+			Input:    Args([]byte(strings.Repeat("a", 40)+"b"), []byte(strings.Repeat("a", 40)+"c")),
+			Expected: "...\n00000020",
+		},
+	}).Comparer(Contains).Test(t)
+
+	eq := func(args ...interface{}) (interface{}, error) {
+		ok, _ := Equal(args[0], args[1])
+		return ok, nil
+	}
+	type myBytes []byte
+	New(eq, Cases{
+		"array and slice": {
+			Input:    Args([4]byte{1, 2, 3, 4}, []byte{1, 2, 3, 4}),
+			Expected: false,
+		},
+		"nil and empty": {
+			Input:    Args([]byte(nil), []byte{}),
+			Expected: false,
+		},
+		"named type": {
+			Input:    Args(myBytes{1}, []byte{1}),
+			Expected: false,
+		},
+		"same bytes": {
+			Input:    Args(myBytes{1}, myBytes{1}),
+			Expected: true,
+		},
+	}).SubTest(t)
+}
@@ -0,0 +1,32 @@
+package trial
+
+// Setup registers fn to run before each case. The case fails without
+// calling the TestFunc when fn returns an error
+func (t *Trial) Setup(fn func() error) *Trial {
+	t.setups = append(t.setups, fn)
+	return t
+}
+
+// Teardown registers fn to run after each case, even when it panics.
+// Teardowns are run in the reverse order they are registered, like defer
+func (t *Trial) Teardown(fn func()) *Trial {
+	t.teardowns = append(t.teardowns, fn)
+	return t
+}
+
+// setup runs all the setup funcs stopping at the first error
+func (t *Trial) setup() error {
+	for _, fn := range t.setups {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// teardown runs all teardown funcs in reverse order
+func (t *Trial) teardown() {
+	for i := len(t.teardowns) - 1; i >= 0; i-- {
+		t.teardowns[i]()
+	}
+}
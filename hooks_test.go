@@ -0,0 +1,55 @@
+package trial
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTrial_SetupTeardown(t *testing.T) {
+	var calls []string
+	fn := func(args ...interface{}) (interface{}, error) {
+		calls = append(calls, "test")
+		if args[0] == "panic" {
+			panic("test panic")
+		}
+		return args[0], nil
+	}
+	var setupErr error
+	tr := New(fn, nil).
+		Setup(func() error {
+			calls = append(calls, "setup")
+			return setupErr
+		}).
+		Teardown(func() { calls = append(calls, "teardown 1") }).
+		Teardown(func() { calls = append(calls, "teardown 2") })
+
+	if r := tr.testCase("run", Case{Input: "a", Expected: "a"}); !r.Success {
+		t.Error("FAIL:", r.Message)
+	}
+	if eq, diff := Equal(calls, []string{"setup", "test", "teardown 2", "teardown 1"}); !eq {
+		t.Error("FAIL: call order", diff)
+	}
+
+	calls = nil
+	if r := tr.testCase("panic", Case{Input: "panic", ShouldPanic: true}); !r.Success {
+		t.Error("FAIL:", r.Message)
+	}
+	if eq, diff := Equal(calls, []string{"setup", "test", "teardown 2", "teardown 1"}); !eq {
+		t.Error("FAIL: teardown after panic", diff)
+	}
+
+	calls, setupErr = nil, errors.New("no database")
+	r := tr.testCase("setup error", Case{Input: "a", Expected: "a"})
+	if r.Success || !strings.Contains(r.Message, `FAIL: "setup error" setup failed: no database`) {
+		t.Error("FAIL: setup error not reported", r.Message)
+	}
+	if eq, diff := Equal(calls, []string{"setup", "teardown 2", "teardown 1"}); !eq {
+		t.Error("FAIL: TestFunc called after setup error", diff)
+	}
+
+	r = tr.testCase("setup error with panic", Case{Input: "panic", ShouldPanic: true})
+	if eq, diff := Equal(r, result{false, `FAIL: "setup error with panic" setup failed: no database`}); !eq {
+		t.Error("FAIL: setup error hidden by ShouldPanic", diff)
+	}
+}
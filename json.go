@@ -0,0 +1,42 @@
+package trial
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EqualJSON compares the JSON representation of actual and expected ignoring
+// map key order and whitespace. Strings and []byte containing valid JSON are
+// used as is, any other value is marshaled to JSON first. Differences are shown
+// for each JSON field.
+func EqualJSON(actual, expected interface{}) (bool, string) {
+	a, err := normalizeJSON(actual)
+	if err != nil {
+		return false, fmt.Sprintf("actual %v", err)
+	}
+	e, err := normalizeJSON(expected)
+	if err != nil {
+		return false, fmt.Sprintf("expected %v", err)
+	}
+	return Equal(a, e)
+}
+
+// normalizeJSON converts v into the generic types of decoded JSON
+func normalizeJSON(v interface{}) (interface{}, error) {
+	var b []byte
+	switch s := v.(type) {
+	case string:
+		b = []byte(s)
+	case []byte:
+		b = s
+	}
+	if b == nil || !json.Valid(b) {
+		var err error
+		if b, err = json.Marshal(v); err != nil {
+			return nil, fmt.Errorf("json marshal: %v", err)
+		}
+	}
+	var i interface{}
+	err := json.Unmarshal(b, &i)
+	return i, err
+}
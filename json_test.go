@@ -0,0 +1,53 @@
+package trial
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqualJSON(t *testing.T) {
+	type user struct {
+		Name  string            `json:"name"`
+		Tags  []string          `json:"tags"`
+		Attrs map[string]string `json:"attrs"`
+		id    int
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		eq, _ := EqualJSON(args[0], args[1])
+		return eq, nil
+	}
+	New(fn, Cases{
+		"struct and json string": {
+			Input:    Args(user{Name: "bob", Tags: []string{"a"}, id: 1}, `{"tags": ["a"], "name": "bob", "attrs": null}`),
+			Expected: true,
+		},
+		"whitespace and key order": {
+			Input:    Args(`{"a":1,"b":{"c":[1,2]}}`, "{\n  \"b\": {\"c\": [1, 2]},\n  \"a\": 1\n}"),
+			Expected: true,
+		},
+		"json bytes": {
+			Input:    Args([]byte(`{"a":1}`), map[string]int{"a": 1}),
+			Expected: true,
+		},
+		"unexported fields ignored": {
+			Input:    Args(user{Name: "bob", id: 1}, user{Name: "bob", id: 2}),
+			Expected: true,
+		},
+		"field differs": {
+			Input:    Args(user{Name: "bob"}, user{Name: "alice"}),
+			Expected: false,
+		},
+		"plain strings": {
+			Input:    Args("hello", "hello"),
+			Expected: true,
+		},
+	}).Test(t)
+
+	_, diff := EqualJSON(user{Name: "bob", Attrs: map[string]string{"role": "admin"}}, `{"name":"bob","tags":null,"attrs":{"role":"user"}}`)
+	if !strings.Contains(diff, `"role"`) || !strings.Contains(diff, `"admin"`) {
+		t.Errorf("FAIL: field difference not shown %q", diff)
+	}
+	if _, diff := EqualJSON(make(chan int), "{}"); !strings.Contains(diff, "actual json marshal:") {
+		t.Errorf("FAIL: marshal error not shown %q", diff)
+	}
+}
@@ -0,0 +1,124 @@
+package trial
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JUnit writes a JUnit XML report of all cases to path after the trial has run
+// so CI systems can show the results of each case.
+func (t *Trial) JUnit(path string) *Trial {
+	t.junitPath = path
+	return t
+}
+
+// outcome is the result of a completed case
+type outcome struct {
+	name     string
+	result   result
+	duration time.Duration
+}
+
+// outcomes collects the outcome of each case
+type outcomes struct {
+	mu   sync.Mutex
+	list []outcome
+}
+
+func (o *outcomes) add(c outcome) {
+	o.mu.Lock()
+	o.list = append(o.list, c)
+	o.mu.Unlock()
+}
+
+// reset removes the outcomes of a previous run
+func (o *outcomes) reset() {
+	o.mu.Lock()
+	o.list = nil
+	o.mu.Unlock()
+}
+
+// sorted returns a copy of all outcomes sorted by name
+func (o *outcomes) sorted() []outcome {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	list := append([]outcome(nil), o.list...)
+	sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+	return list
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// writeJUnit writes the outcomes of all cases as a JUnit XML report
+func (t *Trial) writeJUnit(suite string) error {
+	s := junitSuite{Name: xmlText(suite)}
+	var total time.Duration
+	for _, o := range t.outcomes.sorted() {
+		c := junitCase{
+			Name:      xmlText(o.name),
+			Classname: s.Name,
+			Time:      junitTime(o.duration),
+		}
+		if !o.result.Success {
+			s.Failures++
+			msg := xmlText(o.result.Message)
+			c.Failure = &junitFailure{Message: strings.SplitN(msg, "\n", 2)[0], Body: msg}
+		}
+		total += o.duration
+		s.Cases = append(s.Cases, c)
+	}
+	s.Tests = len(s.Cases)
+	s.Time = junitTime(total)
+
+	b, err := xml.MarshalIndent(junitSuites{Suites: []junitSuite{s}}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("junit report: %v", err)
+	}
+	if err := ioutil.WriteFile(t.junitPath, append([]byte(xml.Header), b...), 0644); err != nil {
+		return fmt.Errorf("junit report: %v", err)
+	}
+	return nil
+}
+
+// junitTime formats a duration in seconds
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// xmlText removes characters that are not allowed in XML
+func xmlText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || (r >= 0x20 && r != 0xFFFE && r != 0xFFFF) {
+			return r
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,62 @@
+package trial
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrial_JUnit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trial")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "report.xml")
+
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	tr := New(fn, nil).JUnit(path)
+	tr.runCase(t, "pass", Case{Input: 1, Expected: 1})
+	tr.runCase(t, "fail <\x1b>", Case{Input: 1, Expected: 2})
+	if err := tr.writeJUnit("TestSuite"); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report junitSuites
+	if err := xml.Unmarshal(b, &report); err != nil {
+		t.Fatalf("FAIL: invalid xml %v\n%s", err, b)
+	}
+	if len(report.Suites) != 1 {
+		t.Fatalf("FAIL: expected 1 suite %s", b)
+	}
+	s := report.Suites[0]
+	if s.Name != "TestSuite" || s.Tests != 2 || s.Failures != 1 {
+		t.Errorf("FAIL: suite %+v", s)
+	}
+	if c := s.Cases[0]; c.Name != "fail <>" || c.Failure == nil || c.Failure.Message != `FAIL: "fail <\x1b>" ` {
+		t.Errorf("FAIL: failed case %+v", c)
+	}
+	if c := s.Cases[1]; c.Name != "pass" || c.Failure != nil || c.Time == "" {
+		t.Errorf("FAIL: passed case %+v", c)
+	}
+
+	// a second run replaces the outcomes of the first
+	tr = New(fn, Cases{"a": {Input: 1, Expected: 1}}).JUnit(path)
+	tr.Test(t)
+	tr.Test(t)
+	if b, err = ioutil.ReadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	var rerun junitSuites
+	if err := xml.Unmarshal(b, &rerun); err != nil || len(rerun.Suites) != 1 || rerun.Suites[0].Tests != 1 {
+		t.Errorf("FAIL: rerun report %v\n%s", err, b)
+	}
+}
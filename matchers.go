@@ -0,0 +1,416 @@
+package trial
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type convertible struct {
+	typ reflect.Type
+}
+
+// ConvertibleTo is used as an Expected value to check that the result
+// can be converted to the type of typ.
+// eg: ConvertibleTo(int64(0)), ConvertibleTo("")
+func ConvertibleTo(typ interface{}) Comparer {
+	return convertible{typ: reflect.TypeOf(typ)}
+}
+
+func (c convertible) Equals(actual interface{}) (bool, string) {
+	t := reflect.TypeOf(actual)
+	if t != nil && c.typ != nil && t.ConvertibleTo(c.typ) {
+		return true, ""
+	}
+	return false, fmt.Sprintf("%v is not convertible to %v", t, c.typ)
+}
+
+type lenBetween struct {
+	min, max int
+}
+
+// LenBetween is used as an Expected value to check that the length of
+// a slice, array, map, string or channel is between min and max (inclusive)
+func LenBetween(min, max int) Comparer {
+	return lenBetween{min: min, max: max}
+}
+
+func (l lenBetween) Equals(actual interface{}) (bool, string) {
+	n, ok := length(actual)
+	if !ok {
+		return false, fmt.Sprintf("type mismatch %T has no length", actual)
+	}
+	if n < l.min || n > l.max {
+		return false, fmt.Sprintf("length %d, expected between %d and %d", n, l.min, l.max)
+	}
+	return true, ""
+}
+
+type lengthEqual int
+
+// Length is used as an Expected value to check the length of a
+// slice, array, map, string or channel without comparing its contents
+func Length(n int) Comparer {
+	return lengthEqual(n)
+}
+
+func (l lengthEqual) Equals(actual interface{}) (bool, string) {
+	n, ok := length(actual)
+	if !ok {
+		return false, fmt.Sprintf("type mismatch %T has no length", actual)
+	}
+	if n != int(l) {
+		return false, fmt.Sprintf("length %d, expected %d", n, l)
+	}
+	return true, ""
+}
+
+// length of a slice, array, map, string or channel
+func length(i interface{}) (int, bool) {
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+type templateMatch struct {
+	tmpl string
+	data interface{}
+}
+
+// EqualTemplate is used as an Expected value where the expected string
+// is created by executing the text/template tmpl with data
+func EqualTemplate(tmpl string, data interface{}) Comparer {
+	return templateMatch{tmpl: tmpl, data: data}
+}
+
+func (m templateMatch) Equals(actual interface{}) (bool, string) {
+	tmpl, err := template.New("expected").Parse(m.tmpl)
+	if err != nil {
+		return false, fmt.Sprintf("template setup failed: %v", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, m.data); err != nil {
+		return false, fmt.Sprintf("template setup failed: %v", err)
+	}
+	return Equal(actual, b.String())
+}
+
+// Sign matchers are used as an Expected value to check the sign of a numeric result
+var (
+	Positive    Comparer = sign{name: "positive (> 0)", ok: func(f float64) bool { return f > 0 }}
+	Negative    Comparer = sign{name: "negative (< 0)", ok: func(f float64) bool { return f < 0 }}
+	NonNegative Comparer = sign{name: "non-negative (>= 0)", ok: func(f float64) bool { return f >= 0 }}
+	NonPositive Comparer = sign{name: "non-positive (<= 0)", ok: func(f float64) bool { return f <= 0 }}
+)
+
+type sign struct {
+	name string
+	ok   func(float64) bool
+}
+
+func (s sign) Equals(actual interface{}) (bool, string) {
+	f, ok := toFloat(actual)
+	if !ok {
+		return false, fmt.Sprintf("type mismatch %T is not a number", actual)
+	}
+	if !s.ok(f) {
+		return false, fmt.Sprintf("got %v, expected %s", actual, s.name)
+	}
+	return true, ""
+}
+
+type between struct {
+	min, max interface{}
+}
+
+// Between is used as an Expected value to check that a number or
+// time.Time result is between min and max (inclusive)
+func Between(min, max interface{}) Comparer {
+	return between{min: min, max: max}
+}
+
+func (b between) Equals(actual interface{}) (bool, string) {
+	lo, ok1 := order(actual, b.min)
+	hi, ok2 := order(actual, b.max)
+	if (!ok1 || !ok2) && !hasNaN(actual, b.min, b.max) {
+		return false, fmt.Sprintf("type mismatch %T is not comparable with %T and %T", actual, b.min, b.max)
+	}
+	if !ok1 || !ok2 || lo < 0 || hi > 0 {
+		return false, fmt.Sprintf("got %v, expected between %v and %v", actual, b.min, b.max)
+	}
+	return true, ""
+}
+
+type threshold struct {
+	op    string
+	value interface{}
+	ok    func(cmp int) bool
+}
+
+// GreaterThan is used as an Expected value to check that a number or
+// time.Time result is greater than v
+func GreaterThan(v interface{}) Comparer {
+	return threshold{op: ">", value: v, ok: func(c int) bool { return c > 0 }}
+}
+
+// GreaterOrEqual is used as an Expected value to check that a number or
+// time.Time result is greater than or equal to v
+func GreaterOrEqual(v interface{}) Comparer {
+	return threshold{op: ">=", value: v, ok: func(c int) bool { return c >= 0 }}
+}
+
+// LessThan is used as an Expected value to check that a number or
+// time.Time result is less than v
+func LessThan(v interface{}) Comparer {
+	return threshold{op: "<", value: v, ok: func(c int) bool { return c < 0 }}
+}
+
+// LessOrEqual is used as an Expected value to check that a number or
+// time.Time result is less than or equal to v
+func LessOrEqual(v interface{}) Comparer {
+	return threshold{op: "<=", value: v, ok: func(c int) bool { return c <= 0 }}
+}
+
+func (t threshold) Equals(actual interface{}) (bool, string) {
+	c, ok := order(actual, t.value)
+	if !ok && !hasNaN(actual, t.value) {
+		return false, fmt.Sprintf("type mismatch %T is not comparable with %T", actual, t.value)
+	}
+	if !ok || !t.ok(c) {
+		return false, fmt.Sprintf("got %v, expected %s %v", actual, t.op, t.value)
+	}
+	return true, ""
+}
+
+// order compares two numbers or two time.Time values returning -1, 0 or 1
+// when x is less than, equal to or greater than y. NaN can't be ordered
+func order(x, y interface{}) (int, bool) {
+	if a, isTime := x.(time.Time); isTime {
+		b, ok := y.(time.Time)
+		switch {
+		case !ok:
+			return 0, false
+		case a.Before(b):
+			return -1, true
+		case a.After(b):
+			return 1, true
+		}
+		return 0, true
+	}
+	a, ok1 := toFloat(x)
+	b, ok2 := toFloat(y)
+	switch {
+	case !ok1 || !ok2 || math.IsNaN(a) || math.IsNaN(b):
+		return 0, false
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	}
+	return 0, true
+}
+
+// hasNaN checks if any of the values is a NaN float
+func hasNaN(values ...interface{}) bool {
+	for _, v := range values {
+		if f, ok := toFloat(v); ok && math.IsNaN(f) {
+			return true
+		}
+	}
+	return false
+}
+
+type affix struct {
+	prefix bool
+	s      string
+}
+
+// HasPrefix is used as an Expected value to check that a string
+// (or fmt.Stringer) result starts with prefix
+func HasPrefix(prefix string) Comparer {
+	return affix{prefix: true, s: prefix}
+}
+
+// HasSuffix is used as an Expected value to check that a string
+// (or fmt.Stringer) result ends with suffix
+func HasSuffix(suffix string) Comparer {
+	return affix{s: suffix}
+}
+
+func (a affix) Equals(actual interface{}) (bool, string) {
+	s, ok := actual.(string)
+	if v, isStringer := actual.(fmt.Stringer); !ok && isStringer {
+		s, ok = v.String(), true
+	}
+	if !ok {
+		return false, fmt.Sprintf("type mismatch %T is not a string", actual)
+	}
+	if a.prefix && !strings.HasPrefix(s, a.s) {
+		return false, fmt.Sprintf("%q does not have prefix %q", s, a.s)
+	}
+	if !a.prefix && !strings.HasSuffix(s, a.s) {
+		return false, fmt.Sprintf("%q does not have suffix %q", s, a.s)
+	}
+	return true, ""
+}
+
+// toFloat converts any int, uint or float to a float64
+func toFloat(i interface{}) (float64, bool) {
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+type mapPredicate struct {
+	keys bool
+	pred func(interface{}) bool
+}
+
+// AllValues is used as an Expected value to check that every value of a map
+// satisfies the predicate
+func AllValues(pred func(interface{}) bool) Comparer {
+	return mapPredicate{pred: pred}
+}
+
+// AllKeys is used as an Expected value to check that every key of a map
+// satisfies the predicate
+func AllKeys(pred func(interface{}) bool) Comparer {
+	return mapPredicate{keys: true, pred: pred}
+}
+
+func (m mapPredicate) Equals(actual interface{}) (bool, string) {
+	v := reflect.ValueOf(actual)
+	if v.Kind() != reflect.Map {
+		return false, fmt.Sprintf("type mismatch %T is not a map", actual)
+	}
+	for _, key := range sortedKeys(v) {
+		value := v.MapIndex(key)
+		if m.keys && !m.pred(key.Interface()) {
+			return false, fmt.Sprintf("key %v does not match", key)
+		}
+		if !m.keys && !m.pred(value.Interface()) {
+			return false, fmt.Sprintf("[%v]: value %v does not match", key, value)
+		}
+	}
+	return true, ""
+}
+
+type hasKeys []interface{}
+
+// HasKeys is used as an Expected value to check that each key is present
+// in a map result regardless of their values. Keys can be of any comparable type
+// assignable to the key type of the map
+func HasKeys(keys ...interface{}) Comparer {
+	return hasKeys(keys)
+}
+
+func (h hasKeys) Equals(actual interface{}) (bool, string) {
+	v := reflect.ValueOf(actual)
+	if v.Kind() != reflect.Map {
+		return false, fmt.Sprintf("type mismatch %T is not a map", actual)
+	}
+	d := NewDiff()
+	for _, k := range h {
+		key := reflect.ValueOf(k)
+		if k == nil && canBeNil(v.Type().Key()) {
+			key = reflect.Zero(v.Type().Key())
+		} else if k == nil || !key.Type().AssignableTo(v.Type().Key()) {
+			d.Errorf("key %v type %T is not %v", k, k, v.Type().Key())
+			continue
+		}
+		if !v.MapIndex(key).IsValid() {
+			d.Missing(k)
+		}
+	}
+	return d.Empty(), d.String()
+}
+
+// canBeNil checks if the zero value of typ is nil
+func canBeNil(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return true
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map sorted by their string value
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
+
+type inOrder []func(interface{}) bool
+
+// ContainsInOrder is used as an Expected value to check that a slice or array
+// has elements matching each predicate in the given order. Other elements
+// may appear between the matches.
+func ContainsInOrder(preds ...func(interface{}) bool) Comparer {
+	return inOrder(preds)
+}
+
+func (o inOrder) Equals(actual interface{}) (bool, string) {
+	v := reflect.ValueOf(actual)
+	if !isList(v) {
+		return false, fmt.Sprintf("type mismatch %T is not a slice or array", actual)
+	}
+	values := listValues(v)
+	matched := -1 // index of the element matching the previous predicate
+	for p, pred := range o {
+		i := matched + 1
+		for i < len(values) && !pred(values[i]) {
+			i++
+		}
+		if i == len(values) && p == 0 {
+			return false, "predicate[0] has no match"
+		}
+		if i == len(values) {
+			return false, fmt.Sprintf("predicate[%d] has no match after index %d (matched by predicate[%d])", p, matched, p-1)
+		}
+		matched = i
+	}
+	return true, ""
+}
+
+type anyOf []interface{}
+
+// AnyOf is used as an Expected value when any one of several results is correct.
+// Each value is compared with the trial's comparer (Equal outside of a trial)
+// and all candidates are shown when none match
+func AnyOf(values ...interface{}) Comparer {
+	return anyOf(values)
+}
+
+func (a anyOf) Equals(actual interface{}) (bool, string) {
+	return a.match(actual, Equal)
+}
+
+// match checks if actual matches any of the values using compare
+func (a anyOf) match(actual interface{}, compare CompareFunc) (bool, string) {
+	s := ""
+	for i, v := range a {
+		eq, diff := compare(actual, v)
+		if eq {
+			return true, ""
+		}
+		s += fmt.Sprintf("\ncandidate[%d] %v", i, v) + indentNonEmpty(diff)
+	}
+	return false, fmt.Sprintf("%v matches none of %d candidates", actual, len(a)) + s
+}
@@ -0,0 +1,427 @@
+package trial
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// matchFn checks the actual value (args[0]) against a Comparer (args[1])
+func matchFn(args ...interface{}) (interface{}, error) {
+	b, s := args[1].(Comparer).Equals(args[0])
+	var err error
+	if s != "" {
+		err = errors.New(s)
+	}
+	return b, err
+}
+
+func TestConvertibleTo(t *testing.T) {
+	type myInt int
+	New(matchFn, Cases{
+		"int to int64": {
+			Input:    Args(1, ConvertibleTo(int64(0))),
+			Expected: true,
+		},
+		"named type": {
+			Input:    Args(myInt(1), ConvertibleTo(0)),
+			Expected: true,
+		},
+		"string to int": {
+			Input:       Args("abc", ConvertibleTo(0)),
+			ExpectedErr: errors.New("string is not convertible to int"),
+		},
+		"nil value": {
+			Input:       Args(nil, ConvertibleTo("")),
+			ExpectedErr: errors.New("<nil> is not convertible to string"),
+		},
+	}).Test(t)
+}
+
+func TestLenBetween(t *testing.T) {
+	ch := make(chan int, 5)
+	ch <- 1
+	New(matchFn, Cases{
+		"slice in range": {
+			Input:    Args([]int{1, 2, 3}, LenBetween(1, 3)),
+			Expected: true,
+		},
+		"string in range": {
+			Input:    Args("abc", LenBetween(3, 10)),
+			Expected: true,
+		},
+		"map in range": {
+			Input:    Args(map[string]int{"a": 1}, LenBetween(0, 1)),
+			Expected: true,
+		},
+		"channel in range": {
+			Input:    Args(ch, LenBetween(1, 1)),
+			Expected: true,
+		},
+		"too short": {
+			Input:       Args([]int{}, LenBetween(1, 3)),
+			ExpectedErr: errors.New("length 0, expected between 1 and 3"),
+		},
+		"too long": {
+			Input:       Args([2]int{}, LenBetween(0, 1)),
+			ExpectedErr: errors.New("length 2, expected between 0 and 1"),
+		},
+		"no length": {
+			Input:       Args(12, LenBetween(0, 1)),
+			ExpectedErr: errors.New("type mismatch int has no length"),
+		},
+	}).Test(t)
+}
+
+func TestLength(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	New(matchFn, Cases{
+		"slice": {
+			Input:    Args([]string{"a", "b", "c"}, Length(3)),
+			Expected: true,
+		},
+		"array": {
+			Input:    Args([2]int{}, Length(2)),
+			Expected: true,
+		},
+		"map": {
+			Input:       Args(map[string]int{"a": 1}, Length(2)),
+			ExpectedErr: errors.New("length 1, expected 2"),
+		},
+		"string": {
+			Input:    Args("hello", Length(5)),
+			Expected: true,
+		},
+		"channel": {
+			Input:    Args(ch, Length(1)),
+			Expected: true,
+		},
+		"mismatch": {
+			Input:       Args([]int{1, 2, 3}, Length(5)),
+			ExpectedErr: errors.New("length 3, expected 5"),
+		},
+		"no length": {
+			Input:       Args(1, Length(1)),
+			ExpectedErr: errors.New("type mismatch int has no length"),
+		},
+	}).Test(t)
+}
+
+func TestEqualTemplate(t *testing.T) {
+	data := map[string]interface{}{"Name": "bob", "Count": 3}
+	New(matchFn, Cases{
+		"rendered match": {
+			Input:    Args("hello bob, you have 3 messages", EqualTemplate("hello {{.Name}}, you have {{.Count}} messages", data)),
+			Expected: true,
+		},
+		"rendered mismatch": {
+			Input:     Args("hello alice", EqualTemplate("hello {{.Name}}", data)),
+			ShouldErr: true,
+		},
+		"invalid template": {
+			Input:       Args("hello", EqualTemplate("hello {{.Name", data)),
+			ExpectedErr: errors.New("template setup failed"),
+		},
+		"execution error": {
+			Input:       Args("hello", EqualTemplate("hello {{.Name.First}}", struct{ Name string }{"bob"})),
+			ExpectedErr: errors.New("template setup failed"),
+		},
+	}).Test(t)
+}
+
+func TestSign(t *testing.T) {
+	New(matchFn, Cases{
+		"positive int": {
+			Input:    Args(1, Positive),
+			Expected: true,
+		},
+		"zero is not positive": {
+			Input:       Args(0, Positive),
+			ExpectedErr: errors.New("got 0, expected positive (> 0)"),
+		},
+		"negative float": {
+			Input:    Args(-0.5, Negative),
+			Expected: true,
+		},
+		"uint is not negative": {
+			Input:       Args(uint8(3), Negative),
+			ExpectedErr: errors.New("got 3, expected negative (< 0)"),
+		},
+		"zero is non-negative": {
+			Input:    Args(0, NonNegative),
+			Expected: true,
+		},
+		"zero is non-positive": {
+			Input:    Args(int64(0), NonPositive),
+			Expected: true,
+		},
+		"positive is not non-positive": {
+			Input:       Args(float32(1), NonPositive),
+			ExpectedErr: errors.New("got 1, expected non-positive (<= 0)"),
+		},
+		"not a number": {
+			Input:       Args("1", Positive),
+			ExpectedErr: errors.New("type mismatch string is not a number"),
+		},
+	}).Test(t)
+}
+
+func TestBetween(t *testing.T) {
+	t1 := TimeHour("2020-01-01T00")
+	New(matchFn, Cases{
+		"int in range": {
+			Input:    Args(5, Between(1, 10)),
+			Expected: true,
+		},
+		"inclusive bounds": {
+			Input:    Args(10, Between(1, 10)),
+			Expected: true,
+		},
+		"mixed number types": {
+			Input:    Args(uint8(3), Between(2.5, 3.5)),
+			Expected: true,
+		},
+		"float out of range": {
+			Input:       Args(1.5, Between(2, 3)),
+			ExpectedErr: errors.New("got 1.5, expected between 2 and 3"),
+		},
+		"time in range": {
+			Input:    Args(t1.Add(time.Minute), Between(t1, t1.Add(time.Hour))),
+			Expected: true,
+		},
+		"time out of range": {
+			Input:       Args(t1.Add(-time.Second), Between(t1, t1.Add(time.Hour))),
+			ExpectedErr: errors.New("got 2019-12-31 23:59:59 +0000 UTC, expected between 2020-01-01 00:00:00 +0000 UTC and"),
+		},
+		"type mismatch": {
+			Input:       Args("a", Between(1, 2)),
+			ExpectedErr: errors.New("type mismatch string is not comparable with int and int"),
+		},
+		"time with number bounds": {
+			Input:       Args(t1, Between(1, 2)),
+			ExpectedErr: errors.New("type mismatch time.Time"),
+		},
+		"NaN": {
+			Input:       Args(math.NaN(), Between(1, 2)),
+			ExpectedErr: errors.New("got NaN, expected between 1 and 2"),
+		},
+	}).Test(t)
+}
+
+func TestThresholds(t *testing.T) {
+	t1 := TimeHour("2020-01-01T00")
+	New(matchFn, Cases{
+		"greater than": {
+			Input:    Args(5, GreaterThan(4)),
+			Expected: true,
+		},
+		"not greater than equal": {
+			Input:       Args(5, GreaterThan(5)),
+			ExpectedErr: errors.New("got 5, expected > 5"),
+		},
+		"greater or equal": {
+			Input:    Args(uint(5), GreaterOrEqual(5)),
+			Expected: true,
+		},
+		"less than float": {
+			Input:    Args(1.5, LessThan(2)),
+			Expected: true,
+		},
+		"not less than": {
+			Input:       Args(int8(3), LessThan(2.5)),
+			ExpectedErr: errors.New("got 3, expected < 2.5"),
+		},
+		"less or equal": {
+			Input:    Args(2.5, LessOrEqual(2.5)),
+			Expected: true,
+		},
+		"not less or equal": {
+			Input:       Args(3, LessOrEqual(2)),
+			ExpectedErr: errors.New("got 3, expected <= 2"),
+		},
+		"time after": {
+			Input:    Args(t1.Add(time.Second), GreaterThan(t1)),
+			Expected: true,
+		},
+		"time not before": {
+			Input:       Args(t1, LessThan(t1)),
+			ExpectedErr: errors.New("got 2020-01-01 00:00:00 +0000 UTC, expected < 2020-01-01 00:00:00 +0000 UTC"),
+		},
+		"type mismatch": {
+			Input:       Args("a", GreaterThan(1)),
+			ExpectedErr: errors.New("type mismatch string is not comparable with int"),
+		},
+		"time with number": {
+			Input:       Args(t1, LessThan(1)),
+			ExpectedErr: errors.New("type mismatch time.Time is not comparable with int"),
+		},
+		"NaN": {
+			Input:       Args(math.NaN(), GreaterOrEqual(0)),
+			ExpectedErr: errors.New("got NaN, expected >= 0"),
+		},
+		"NaN threshold": {
+			Input:       Args(1, LessThan(math.NaN())),
+			ExpectedErr: errors.New("got 1, expected < NaN"),
+		},
+	}).Test(t)
+}
+
+func TestHasKeys(t *testing.T) {
+	type point struct{ X, Y int }
+	New(matchFn, Cases{
+		"string keys": {
+			Input:    Args(map[string]int{"a": 1, "b": 0}, HasKeys("a", "b")),
+			Expected: true,
+		},
+		"missing keys": {
+			Input:       Args(map[string]int{"a": 1}, HasKeys("a", "b", "c")),
+			ExpectedErr: errors.New(" - b\n - c"),
+		},
+		"int keys": {
+			Input:    Args(map[int]string{1: "", 2: ""}, HasKeys(2)),
+			Expected: true,
+		},
+		"struct keys": {
+			Input:       Args(map[point]bool{{1, 2}: true}, HasKeys(point{1, 2}, point{2, 1})),
+			ExpectedErr: errors.New(" - {2 1}"),
+		},
+		"interface keys": {
+			Input:    Args(map[interface{}]int{1: 1, "a": 2, nil: 3}, HasKeys("a", 1, nil)),
+			Expected: true,
+		},
+		"wrong key type": {
+			Input:       Args(map[string]int{"1": 1}, HasKeys(1)),
+			ExpectedErr: errors.New("key 1 type int is not string"),
+		},
+		"not a map": {
+			Input:       Args([]string{"a"}, HasKeys("a")),
+			ExpectedErr: errors.New("type mismatch []string is not a map"),
+		},
+	}).Test(t)
+}
+
+func TestAffix(t *testing.T) {
+	New(matchFn, Cases{
+		"prefix": {
+			Input:    Args("ERROR: disk full", HasPrefix("ERROR:")),
+			Expected: true,
+		},
+		"missing prefix": {
+			Input:       Args("WARN: disk full", HasPrefix("ERROR:")),
+			ExpectedErr: errors.New(`"WARN: disk full" does not have prefix "ERROR:"`),
+		},
+		"suffix": {
+			Input:    Args("report.csv", HasSuffix(".csv")),
+			Expected: true,
+		},
+		"missing suffix": {
+			Input:       Args("report.csv", HasSuffix(".json")),
+			ExpectedErr: errors.New(`"report.csv" does not have suffix ".json"`),
+		},
+		"stringer": {
+			Input:    Args(time.Second, HasSuffix("s")),
+			Expected: true,
+		},
+		"not a string": {
+			Input:       Args(12, HasPrefix("1")),
+			ExpectedErr: errors.New("type mismatch int is not a string"),
+		},
+	}).Test(t)
+}
+
+func TestAllValuesKeys(t *testing.T) {
+	positive := func(i interface{}) bool { return i.(int) > 0 }
+	short := func(i interface{}) bool { return len(i.(string)) < 3 }
+	New(matchFn, Cases{
+		"all values match": {
+			Input:    Args(map[string]int{"a": 1, "b": 2}, AllValues(positive)),
+			Expected: true,
+		},
+		"first failing value": {
+			Input:       Args(map[string]int{"a": 1, "b": -2, "c": 0}, AllValues(positive)),
+			ExpectedErr: errors.New("[b]: value -2 does not match"),
+		},
+		"all keys match": {
+			Input:    Args(map[string]int{"a": 1, "bc": 2}, AllKeys(short)),
+			Expected: true,
+		},
+		"failing key": {
+			Input:       Args(map[string]int{"a": 1, "long": 2}, AllKeys(short)),
+			ExpectedErr: errors.New("key long does not match"),
+		},
+		"empty map": {
+			Input:    Args(map[string]int{}, AllValues(positive)),
+			Expected: true,
+		},
+		"not a map": {
+			Input:       Args([]int{1}, AllKeys(positive)),
+			ExpectedErr: errors.New("type mismatch []int is not a map"),
+		},
+	}).Test(t)
+}
+
+func TestContainsInOrder(t *testing.T) {
+	has := func(s string) func(interface{}) bool {
+		return func(i interface{}) bool { return strings.Contains(i.(string), s) }
+	}
+	logs := []string{"start", "connect db", "retry", "connect cache", "ready"}
+	New(matchFn, Cases{
+		"in order": {
+			Input:    Args(logs, ContainsInOrder(has("start"), has("db"), has("ready"))),
+			Expected: true,
+		},
+		"same predicate matches different elements": {
+			Input:    Args(logs, ContainsInOrder(has("connect"), has("connect"))),
+			Expected: true,
+		},
+		"out of order": {
+			Input:       Args(logs, ContainsInOrder(has("cache"), has("db"))),
+			ExpectedErr: errors.New("predicate[1] has no match after index 3 (matched by predicate[0])"),
+		},
+		"no match": {
+			Input:       Args(logs, ContainsInOrder(has("stop"))),
+			ExpectedErr: errors.New("predicate[0] has no match"),
+		},
+		"no predicates": {
+			Input:    Args([]string{}, ContainsInOrder()),
+			Expected: true,
+		},
+		"not a slice": {
+			Input:       Args("start", ContainsInOrder(has("start"))),
+			ExpectedErr: errors.New("type mismatch string is not a slice or array"),
+		},
+	}).Test(t)
+}
+
+func TestAnyOf(t *testing.T) {
+	New(matchFn, Cases{
+		"matches one": {
+			Input:    Args(2, AnyOf(1, 2, 3)),
+			Expected: true,
+		},
+		"matches none": {
+			Input:       Args(4, AnyOf(1, 2)),
+			ExpectedErr: errors.New("4 matches none of 2 candidates\ncandidate[0] 1\n"),
+		},
+		"no candidates": {
+			Input:       Args(1, AnyOf()),
+			ExpectedErr: errors.New("1 matches none of 0 candidates"),
+		},
+	}).Test(t)
+
+	// the trial's comparer is used for each candidate
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	r := New(fn, nil).Comparer(Contains).testCase("contains any", Case{Input: "hello world", Expected: AnyOf("bye", "world")})
+	if !r.Success {
+		t.Error("FAIL:", r.Message)
+	}
+	r = New(fn, nil).Comparer(Contains).testCase("contains none", Case{Input: "hello world", Expected: AnyOf("bye", "moon")})
+	if r.Success || !strings.Contains(r.Message, "candidate[1] moon") {
+		t.Error("FAIL: candidates not listed", r.Message)
+	}
+}
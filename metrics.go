@@ -0,0 +1,73 @@
+package trial
+
+import (
+	"sync"
+)
+
+// MetricsCollector is injected into the code being tested to record metrics
+// that are checked against a case's ExpectedMetrics.
+// Reset is called before each case is run
+type MetricsCollector interface {
+	Metrics() map[string]float64
+	Reset()
+}
+
+// Collector sets the MetricsCollector used to verify the ExpectedMetrics of each case
+func (t *Trial) Collector(c MetricsCollector) *Trial {
+	t.collector = c
+	return t
+}
+
+// checkMetrics compares the collected metrics with the expected values
+func (t *Trial) checkMetrics(msg string, expected map[string]float64) result {
+	if t.collector == nil {
+		return fail("FAIL: %q ExpectedMetrics requires a MetricsCollector", msg)
+	}
+	if equal, diff := Equal(t.collector.Metrics(), expected); !equal {
+		return fail("FAIL: %q metrics \n%s", msg, diff)
+	}
+	return pass("PASS: %q", msg)
+}
+
+// Metrics is a concurrent safe MetricsCollector
+type Metrics struct {
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewMetrics creates an empty Metrics collector
+func NewMetrics() *Metrics {
+	return &Metrics{values: make(map[string]float64)}
+}
+
+// Add increments the named metric by v
+func (m *Metrics) Add(name string, v float64) {
+	m.mu.Lock()
+	m.values[name] += v
+	m.mu.Unlock()
+}
+
+// Set the named metric to v
+func (m *Metrics) Set(name string, v float64) {
+	m.mu.Lock()
+	m.values[name] = v
+	m.mu.Unlock()
+}
+
+// Metrics returns a copy of all recorded metrics
+func (m *Metrics) Metrics() map[string]float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	values := make(map[string]float64, len(m.values))
+	for k, v := range m.values {
+		values[k] = v
+	}
+	return values
+}
+
+// Reset removes all recorded metrics
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	m.values = make(map[string]float64)
+	m.mu.Unlock()
+}
@@ -0,0 +1,46 @@
+package trial
+
+import (
+	"testing"
+)
+
+func TestTrial_Collector(t *testing.T) {
+	m := NewMetrics()
+	fn := func(args ...interface{}) (interface{}, error) {
+		for _, v := range args {
+			m.Add("count", 1)
+			m.Add("total", float64(v.(int)))
+		}
+		return nil, nil
+	}
+	tr := New(fn, nil).Collector(m)
+
+	cases := map[string]struct {
+		Case    Case
+		success bool
+	}{
+		"record metrics": {
+			Case:    Case{Input: Args(1, 2, 3), ExpectedMetrics: map[string]float64{"count": 3, "total": 6}},
+			success: true,
+		},
+		"collector is reset per case": {
+			Case:    Case{Input: Args(4), ExpectedMetrics: map[string]float64{"count": 1, "total": 4}},
+			success: true,
+		},
+		"unexpected metric value": {
+			Case:    Case{Input: Args(1), ExpectedMetrics: map[string]float64{"count": 2, "total": 1}},
+			success: false,
+		},
+	}
+	for msg, test := range cases {
+		// run twice to verify metrics aren't carried over between cases
+		tr.testCase(msg, test.Case)
+		if r := tr.testCase(msg, test.Case); r.Success != test.success {
+			t.Errorf("FAIL: %q %s", msg, r.Message)
+		}
+	}
+
+	if r := New(fn, nil).testCase("no collector", Case{ExpectedMetrics: map[string]float64{}}); r.Success {
+		t.Error("FAIL: ExpectedMetrics without a collector should fail")
+	}
+}
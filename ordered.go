@@ -0,0 +1,77 @@
+package trial
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// OrderedMap is the adapter an ordered map needs to be compared with EqualOrderedMap.
+// Types with the methods Keys() []K and Get(K) V (or Get(K) (V, bool))
+// for any key and value types are also supported.
+type OrderedMap interface {
+	Keys() []interface{}
+	Get(key interface{}) interface{}
+}
+
+// EqualOrderedMap compares ordered maps by their sequence of keys and values
+// instead of their internal fields. The first position where the key or the
+// value differs is reported. Values that are not ordered maps are compared with Equal
+func EqualOrderedMap(actual, expected interface{}) (bool, string) {
+	a, ok := orderedEntries(actual)
+	e, ok2 := orderedEntries(expected)
+	if !ok || !ok2 {
+		return Equal(actual, expected)
+	}
+	for i := 0; i < len(a) && i < len(e); i++ {
+		if eq, diff := Equal(a[i].key, e[i].key); !eq {
+			return false, fmt.Sprintf("key differs at position %d\n%s", i, diff)
+		}
+		if eq, diff := Equal(a[i].value, e[i].value); !eq {
+			return false, fmt.Sprintf("value of key %v differs at position %d\n%s", a[i].key, i, diff)
+		}
+	}
+	if len(a) > len(e) {
+		return false, fmt.Sprintf("extra key %v at position %d", a[len(e)].key, len(e))
+	}
+	if len(a) < len(e) {
+		return false, fmt.Sprintf("missing key %v at position %d", e[len(a)].key, len(a))
+	}
+	return true, ""
+}
+
+type entry struct {
+	key, value interface{}
+}
+
+// orderedEntries lists the key value pairs of an ordered map in order
+func orderedEntries(i interface{}) ([]entry, bool) {
+	if m, ok := i.(OrderedMap); ok {
+		keys := m.Keys()
+		entries := make([]entry, len(keys))
+		for n, k := range keys {
+			entries[n] = entry{key: k, value: m.Get(k)}
+		}
+		return entries, true
+	}
+	// other key and value types are found with reflection
+	v := reflect.ValueOf(i)
+	if !v.IsValid() {
+		return nil, false
+	}
+	keysFn, getFn := v.MethodByName("Keys"), v.MethodByName("Get")
+	if !keysFn.IsValid() || !getFn.IsValid() {
+		return nil, false
+	}
+	kt, gt := keysFn.Type(), getFn.Type()
+	if kt.NumIn() != 0 || kt.NumOut() != 1 || kt.Out(0).Kind() != reflect.Slice ||
+		gt.NumIn() != 1 || gt.NumOut() == 0 || !kt.Out(0).Elem().AssignableTo(gt.In(0)) {
+		return nil, false
+	}
+	keys := keysFn.Call(nil)[0]
+	entries := make([]entry, keys.Len())
+	for n := 0; n < keys.Len(); n++ {
+		k := keys.Index(n)
+		entries[n] = entry{key: k.Interface(), value: getFn.Call([]reflect.Value{k})[0].Interface()}
+	}
+	return entries, true
+}
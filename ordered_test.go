@@ -0,0 +1,90 @@
+package trial
+
+import (
+	"errors"
+	"testing"
+)
+
+// orderedMap keeps the insertion order of its keys
+type orderedMap struct {
+	keys   []string
+	values map[string]int
+}
+
+func newOrderedMap(kv ...interface{}) *orderedMap {
+	m := &orderedMap{values: make(map[string]int)}
+	for i := 0; i < len(kv); i += 2 {
+		k := kv[i].(string)
+		m.keys = append(m.keys, k)
+		m.values[k] = kv[i+1].(int)
+	}
+	return m
+}
+
+func (m *orderedMap) Keys() []string { return m.keys }
+
+func (m *orderedMap) Get(k string) (int, bool) {
+	v, ok := m.values[k]
+	return v, ok
+}
+
+// pairs implements OrderedMap
+type pairs []interface{}
+
+func (p pairs) Keys() []interface{} {
+	keys := make([]interface{}, 0)
+	for i := 0; i < len(p); i += 2 {
+		keys = append(keys, p[i])
+	}
+	return keys
+}
+
+func (p pairs) Get(key interface{}) interface{} {
+	for i := 0; i < len(p); i += 2 {
+		if p[i] == key {
+			return p[i+1]
+		}
+	}
+	return nil
+}
+
+func TestEqualOrderedMap(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		b, s := EqualOrderedMap(args[0], args[1])
+		var err error
+		if s != "" {
+			err = errors.New(s)
+		}
+		return b, err
+	}
+	New(fn, Cases{
+		"equal": {
+			Input:    Args(newOrderedMap("a", 1, "b", 2), newOrderedMap("a", 1, "b", 2)),
+			Expected: true,
+		},
+		"order differs": {
+			Input:       Args(newOrderedMap("a", 1, "b", 2), newOrderedMap("b", 2, "a", 1)),
+			ExpectedErr: errors.New("key differs at position 0"),
+		},
+		"value differs": {
+			Input:       Args(newOrderedMap("a", 1, "b", 2), newOrderedMap("a", 1, "b", 3)),
+			ExpectedErr: errors.New("value of key b differs at position 1"),
+		},
+		"extra key": {
+			Input:       Args(newOrderedMap("a", 1, "b", 2), newOrderedMap("a", 1)),
+			ExpectedErr: errors.New("extra key b at position 1"),
+		},
+		"missing key": {
+			Input:       Args(newOrderedMap(), newOrderedMap("a", 1)),
+			ExpectedErr: errors.New("missing key a at position 0"),
+		},
+		"OrderedMap": {
+			Input:       Args(pairs{"a", 1, "b", 2}, pairs{"a", 1, "b", 3}),
+			ExpectedErr: errors.New("value of key b differs at position 1"),
+		},
+		"not ordered maps": {
+			Input:    Args(map[string]int{"a": 1}, map[string]int{"a": 1}),
+			Expected: true,
+		},
+	}).Test(t)
+}
@@ -0,0 +1,14 @@
+package trial
+
+// Parallel runs each case of SubTest in parallel with the other cases (see testing.T.Parallel).
+// The TestFunc must be safe to call concurrently and any value shared
+// between cases, such as a MetricsCollector, will be used by multiple cases at once.
+// Sequential trials are not run in parallel
+func (t *Trial) Parallel() *Trial {
+	t.parallel = true
+	return t
+}
+
+func (t *Trial) runParallel() bool {
+	return t.parallel && !t.sequential
+}
@@ -0,0 +1,43 @@
+package trial
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestTrial_Parallel(t *testing.T) {
+	// parallel subtests are paused until SubTest returns
+	var mu sync.Mutex
+	returned, inOrder := false, true
+	calls := 0
+	fn := func(args ...interface{}) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		inOrder = inOrder && returned
+		return args[1], nil
+	}
+	var closed bool
+	t.Run("cases", func(t *testing.T) {
+		New(fn, Cases{
+			"a": {Input: 1, Expected: 1},
+			"b": {Input: 2, Expected: 2},
+			"c": {Input: 3, Expected: 3},
+		}).Parallel().SharedFixture(func() (interface{}, func()) {
+			return nil, func() {
+				mu.Lock()
+				closed = calls == 3
+				mu.Unlock()
+			}
+		}).SubTest(t)
+		mu.Lock()
+		returned = true
+		mu.Unlock()
+	})
+	if calls != 3 || !inOrder {
+		t.Errorf("FAIL: cases were not run in parallel after SubTest returned (%d calls)", calls)
+	}
+	if !closed {
+		t.Error("FAIL: fixture not closed after all parallel cases")
+	}
+}
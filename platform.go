@@ -0,0 +1,27 @@
+package trial
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// platformSkip returns the reason a case is skipped on the current platform
+// or an empty string if it should run
+func (c Case) platformSkip() string {
+	if len(c.GOOS) > 0 && !hasString(c.GOOS, runtime.GOOS) {
+		return fmt.Sprintf("only runs on GOOS %v (running %s)", c.GOOS, runtime.GOOS)
+	}
+	if len(c.GOARCH) > 0 && !hasString(c.GOARCH, runtime.GOARCH) {
+		return fmt.Sprintf("only runs on GOARCH %v (running %s)", c.GOARCH, runtime.GOARCH)
+	}
+	return ""
+}
+
+func hasString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package trial
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestCase_Platform(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	tr := New(fn, nil)
+	cases := map[string]struct {
+		Case    Case
+		skipped bool
+	}{
+		"current GOOS": {
+			Case: Case{Input: 1, Expected: 1, GOOS: []string{"plan9", runtime.GOOS}},
+		},
+		"other GOOS": {
+			Case:    Case{Input: 1, Expected: 2, GOOS: []string{"plan9"}},
+			skipped: true,
+		},
+		"current GOARCH": {
+			Case: Case{Input: 1, Expected: 1, GOARCH: []string{runtime.GOARCH}},
+		},
+		"other GOARCH": {
+			Case:    Case{Input: 1, Expected: 2, GOOS: []string{runtime.GOOS}, GOARCH: []string{"mips"}},
+			skipped: true,
+		},
+	}
+	for msg, test := range cases {
+		r := tr.testCase(msg, test.Case)
+		if !r.Success || r.skipped() != test.skipped {
+			t.Errorf("FAIL: %q %v", msg, r.Message)
+		}
+	}
+
+	r := tr.testCase("plan9 only", Case{Input: 1, GOOS: []string{"plan9"}})
+	if eq, diff := Equal(r.Message, `SKIP: "plan9 only" only runs on GOOS [plan9] (running `+runtime.GOOS+")"); !eq {
+		t.Error("FAIL:", diff)
+	}
+}
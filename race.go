@@ -0,0 +1,70 @@
+package trial
+
+import (
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// raceEnv is set in the subprocess running a single case with DetectRaces
+const raceEnv = "TRIAL_RACE_CASE"
+
+// DetectRaces runs every case in its own subprocess when the tests are
+// built with -race, so a data race fails the case where it occurred
+// rather than the whole test. The test binary is re-run for each case
+// and only the case being checked is run in the subprocess.
+// Without -race cases run normally.
+func (t *Trial) DetectRaces() *Trial {
+	t.detectRaces = true
+	return t
+}
+
+// skipCase checks if the case should be skipped because the
+// test is a subprocess for another case
+func skipCase(name string) bool {
+	c := os.Getenv(raceEnv)
+	return c != "" && c != name
+}
+
+// isRaceCase checks if the case should be run in a subprocess to detect races
+func (t *Trial) isRaceCase() bool {
+	return t.detectRaces && raceEnabled && os.Getenv(raceEnv) == ""
+}
+
+// raceCase runs the test binary for a single case and reports any data races
+func raceCase(testName, msg string) result {
+	cmd := exec.Command(os.Args[0], "-test.run="+testPattern(testName), "-test.count=1")
+	cmd.Env = append(os.Environ(), raceEnv+"="+msg)
+	out, err := cmd.CombinedOutput()
+	if report := raceReport(string(out)); report != "" {
+		return fail("FAIL: %q data race detected\n%s", msg, report)
+	}
+	if err != nil {
+		return fail("FAIL: %q \n%s", msg, strings.TrimSpace(string(out)))
+	}
+	return pass("PASS: %q", msg)
+}
+
+// testPattern creates a -test.run pattern that only matches the named test
+func testPattern(name string) string {
+	parts := strings.Split(name, "/")
+	for i, p := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(p) + "$"
+	}
+	return strings.Join(parts, "/")
+}
+
+// raceReport extracts the race detector's warnings from the output
+func raceReport(out string) string {
+	const start, end = "WARNING: DATA RACE", "=================="
+	i := strings.Index(out, start)
+	if i == -1 {
+		return ""
+	}
+	report := out[i:]
+	if j := strings.Index(report, end); j != -1 {
+		report = report[:j]
+	}
+	return strings.TrimSpace(report)
+}
@@ -0,0 +1,7 @@
+//go:build !race
+// +build !race
+
+package trial
+
+// raceEnabled is true when built with the -race flag
+const raceEnabled = false
@@ -0,0 +1,60 @@
+package trial
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTrial_DetectRaces(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		x := args[0].(int)
+		if args[1].(bool) {
+			done := make(chan struct{})
+			go func() {
+				x++
+				close(done)
+			}()
+			x++
+			<-done
+			return 2, nil
+		}
+		return x + 1, nil
+	}
+	tr := New(fn, Cases{
+		"no race": {Input: Args(1, false), Expected: 2},
+		"race":    {Input: Args(0, true), Expected: 2},
+	}).DetectRaces()
+
+	if os.Getenv(raceEnv) != "" {
+		// subprocess running a single case
+		tr.Test(t)
+		return
+	}
+
+	if r := tr.runCase(t, "no race", tr.cases["no race"]); !r.Success {
+		t.Errorf("FAIL: %s", r.Message)
+	}
+	if !raceEnabled {
+		t.Skip("requires -race to detect races")
+	}
+	r := tr.runCase(t, "race", tr.cases["race"])
+	if r.Success || !strings.Contains(r.Message, "data race detected\nWARNING: DATA RACE") {
+		t.Errorf("FAIL: race not detected %s", r.Message)
+	}
+}
+
+func TestTestPattern(t *testing.T) {
+	New(func(args ...interface{}) (interface{}, error) {
+		return testPattern(args[0].(string)), nil
+	}, Cases{
+		"test name": {
+			Input:    "TestAdd",
+			Expected: "^TestAdd$",
+		},
+		"subtest": {
+			Input:    "TestAdd/one+two",
+			Expected: `^TestAdd$/^one\+two$`,
+		},
+	}).Test(t)
+}
@@ -0,0 +1,25 @@
+package trial
+
+import (
+	"math/rand"
+)
+
+// WithRand passes a *rand.Rand seeded with seed to the TestFunc as the
+// first argument (after any SharedFixture). A new source is created
+// for each case so results don't depend on the order cases are run.
+// Use Rand(args...) to retrieve the source within the TestFunc.
+func (t *Trial) WithRand(seed int64) *Trial {
+	t.seed = &seed
+	return t
+}
+
+// Rand returns the *rand.Rand injected by WithRand from the args
+// of a TestFunc, nil is returned if no source was injected.
+func Rand(args ...interface{}) *rand.Rand {
+	for _, arg := range args {
+		if r, ok := arg.(*rand.Rand); ok {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package trial
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTrial_WithRand(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		r := Rand(args...)
+		values := make([]int, args[1].(int))
+		for i := range values {
+			values[i] = r.Intn(100)
+		}
+		return values, nil
+	}
+	r := rand.New(rand.NewSource(1))
+	expected := []int{r.Intn(100), r.Intn(100), r.Intn(100)}
+
+	New(fn, Cases{
+		"seeded values": {
+			Input:    3,
+			Expected: expected,
+		},
+		"new source per case": {
+			Input:    2,
+			Expected: expected[:2],
+		},
+	}).WithRand(1).Test(t)
+
+	if Rand(1, "a") != nil {
+		t.Error("FAIL: Rand without an injected source should be nil")
+	}
+}
@@ -0,0 +1,39 @@
+package trial
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RegexPrefix marks an Expected string as a regular expression for the Regex comparer.
+// eg: Expected: trial.RegexPrefix + `^id-\d+$`
+const RegexPrefix = "regex:"
+
+// Regex compares the actual string with an Expected *regexp.Regexp or a string
+// starting with RegexPrefix as a pattern. All other Expected values are compared with Equal.
+func Regex(actual, expected interface{}) (bool, string) {
+	var re *regexp.Regexp
+	switch e := expected.(type) {
+	case *regexp.Regexp:
+		re = e
+	case string:
+		if !strings.HasPrefix(e, RegexPrefix) {
+			return Equal(actual, expected)
+		}
+		var err error
+		if re, err = regexp.Compile(strings.TrimPrefix(e, RegexPrefix)); err != nil {
+			return false, fmt.Sprintf("invalid pattern: %v", err)
+		}
+	default:
+		return Equal(actual, expected)
+	}
+	s, ok := actual.(string)
+	if !ok {
+		return false, fmt.Sprintf("type mismatch %T is not a string", actual)
+	}
+	if re.MatchString(s) {
+		return true, ""
+	}
+	return false, fmt.Sprintf("%q does not match pattern %q", s, re.String())
+}
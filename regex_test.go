@@ -0,0 +1,44 @@
+package trial
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestRegex(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		b, s := Regex(args[0], args[1])
+		var err error
+		if s != "" {
+			err = errors.New(s)
+		}
+		return b, err
+	}
+	New(fn, Cases{
+		"regexp matches": {
+			Input:    Args("id-123", regexp.MustCompile(`^id-\d+$`)),
+			Expected: true,
+		},
+		"prefix pattern matches": {
+			Input:    Args("connection refused: port 80", RegexPrefix+`port \d+`),
+			Expected: true,
+		},
+		"no match": {
+			Input:       Args("id-abc", RegexPrefix+`^id-\d+$`),
+			ExpectedErr: errors.New(`"id-abc" does not match pattern "^id-\\d+$"`),
+		},
+		"invalid pattern": {
+			Input:       Args("abc", RegexPrefix+`(`),
+			ExpectedErr: errors.New("invalid pattern: error parsing regexp"),
+		},
+		"type mismatch": {
+			Input:       Args(123, RegexPrefix+`\d+`),
+			ExpectedErr: errors.New("type mismatch int is not a string"),
+		},
+		"plain string uses Equal": {
+			Input:    Args("abc", "abc"),
+			Expected: true,
+		},
+	}).Test(t)
+}
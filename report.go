@@ -0,0 +1,49 @@
+package trial
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ReportTo writes a JSON report of all cases to w after the trial has run
+// so results can be aggregated without parsing the test output.
+// The report is an array of {name, pass, message, durationMs} records.
+func (t *Trial) ReportTo(w io.Writer) *Trial {
+	t.reportTo = w
+	return t
+}
+
+type reportRecord struct {
+	Name       string  `json:"name"`
+	Pass       bool    `json:"pass"`
+	Message    string  `json:"message"`
+	DurationMs float64 `json:"durationMs"`
+}
+
+// recordOutcomes checks if the outcome of each case is needed for a report
+func (t *Trial) recordOutcomes() bool {
+	return t.junitPath != "" || t.reportTo != nil
+}
+
+// writeReport writes the outcomes of all cases as a JSON array
+func (t *Trial) writeReport() error {
+	records := make([]reportRecord, 0)
+	for _, o := range t.outcomes.sorted() {
+		records = append(records, reportRecord{
+			Name:       o.name,
+			Pass:       o.result.Success,
+			Message:    o.result.Message,
+			DurationMs: float64(o.duration) / float64(time.Millisecond),
+		})
+	}
+	b, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json report: %v", err)
+	}
+	if _, err := t.reportTo.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("json report: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package trial
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestTrial_ReportTo(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	var buf bytes.Buffer
+	tr := New(fn, nil).ReportTo(&buf)
+	tr.runCase(t, "pass", Case{Input: 1, Expected: 1})
+	tr.runCase(t, "fail", Case{Input: 1, Expected: 2})
+	if err := tr.writeReport(); err != nil {
+		t.Fatal(err)
+	}
+
+	var records []reportRecord
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("FAIL: invalid json %v\n%s", err, buf.String())
+	}
+	for i := range records {
+		if records[i].DurationMs < 0 {
+			t.Errorf("FAIL: negative duration %v", records[i])
+		}
+		records[i].DurationMs = 0
+		records[i].Message = records[i].Message[:4]
+	}
+	expected := []reportRecord{
+		{Name: "fail", Pass: false, Message: "FAIL"},
+		{Name: "pass", Pass: true, Message: "PASS"},
+	}
+	if eq, diff := Equal(records, expected); !eq {
+		t.Error("FAIL: report", diff)
+	}
+
+	// the report is written after Test completes
+	buf.Reset()
+	New(fn, Cases{"a": {Input: 1, Expected: 1}}).ReportTo(&buf).Test(t)
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil || len(records) != 1 || records[0].Name != "a" {
+		t.Errorf("FAIL: report after Test %v %s", err, buf.String())
+	}
+
+	// each run reports only its own cases
+	buf.Reset()
+	tr = New(fn, Cases{"a": {Input: 1, Expected: 1}}).ReportTo(&buf)
+	tr.Test(t)
+	buf.Reset()
+	tr.Test(t)
+	var rerun []reportRecord
+	if err := json.Unmarshal(buf.Bytes(), &rerun); err != nil || len(rerun) != 1 {
+		t.Errorf("FAIL: report after rerun %v %s", err, buf.String())
+	}
+}
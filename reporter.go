@@ -0,0 +1,37 @@
+package trial
+
+import (
+	"github.com/google/go-cmp/cmp"
+)
+
+// DiffReporter is used with cmp.Reporter to control how differences are
+// collected and displayed. PushStep and PopStep are called as cmp walks
+// each value and Report is called with the result of comparing each
+// leaf node (see cmp.Reporter). String returns the differences shown
+// when a case fails.
+type DiffReporter interface {
+	PushStep(cmp.PathStep)
+	Report(cmp.Result)
+	PopStep()
+	String() string
+}
+
+// Reporter sets a func that creates the DiffReporter used to display
+// differences. A factory is taken rather than a single cmp.Reporter because
+// reporters collect state, so a new one is created for each comparison.
+// Reporter, DerefPointers and TreeDiff all set the reporter, only the last one called is used.
+// Only used with the default Equal comparer.
+func (t *Trial) Reporter(fn func() DiffReporter) *Trial {
+	t.reporter = fn
+	return t
+}
+
+// reportEqual compares actual and expected like Equal and returns
+// the differences collected by the reporter
+func reportEqual(actual, expected interface{}, r DiffReporter, opts ...cmp.Option) (bool, string) {
+	opts = append(equalOpts(actual, opts...), cmp.Reporter(r))
+	if cmp.Equal(actual, expected, opts...) {
+		return true, ""
+	}
+	return false, r.String()
+}
@@ -0,0 +1,47 @@
+package trial
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// pathReporter lists each difference with its full path
+type pathReporter struct {
+	path  cmp.Path
+	diffs []string
+}
+
+func (r *pathReporter) PushStep(ps cmp.PathStep) { r.path = append(r.path, ps) }
+func (r *pathReporter) PopStep()                 { r.path = r.path[:len(r.path)-1] }
+func (r *pathReporter) Report(rs cmp.Result) {
+	if !rs.Equal() {
+		vx, vy := r.path.Last().Values()
+		r.diffs = append(r.diffs, fmt.Sprintf("%#v: %v != %v", r.path, vx, vy))
+	}
+}
+func (r *pathReporter) String() string { return strings.Join(r.diffs, "\n") }
+
+func TestTrial_Reporter(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	tr := New(fn, nil).Reporter(func() DiffReporter { return &pathReporter{} })
+
+	r := tr.testCase("custom reporter", Case{Input: point{X: 1, Y: 2}, Expected: point{X: 1, Y: 3}})
+	if eq, diff := Equal(r.Message, "FAIL: \"custom reporter\" \n{trial.point}.Y: 2 != 3"); !eq {
+		t.Error("FAIL:", diff)
+	}
+
+	// a new reporter is used for each comparison
+	r = tr.testCase("second case", Case{Input: point{X: 5}, Expected: point{X: 4}})
+	if eq, diff := Equal(r.Message, "FAIL: \"second case\" \n{trial.point}.X: 5 != 4"); !eq {
+		t.Error("FAIL:", diff)
+	}
+
+	if r := tr.testCase("equal", Case{Input: 1, Expected: 1}); !r.Success {
+		t.Error("FAIL:", r.Message)
+	}
+}
@@ -0,0 +1,34 @@
+package trial
+
+import (
+	"fmt"
+)
+
+// Retry sets the default number of times a failing case is run again
+// before it's reported as failed. A Case's Retry is used when set
+func (t *Trial) Retry(n int) *Trial {
+	t.retry = n
+	return t
+}
+
+// retryCase runs the case until it passes or has used all its retries.
+// Panics count as a failed attempt. The last failure is reported
+func (t *Trial) retryCase(msg string, test Case) result {
+	retries := test.Retry
+	if retries == 0 {
+		retries = t.retry
+	}
+	// each attempt of a Sequential case is compared with the previous case's result
+	prev := t.prev
+	r := t.testCase(msg, test)
+	for attempt := 2; !r.Success && attempt <= retries+1; attempt++ {
+		t.prev = prev
+		if r = t.testCase(msg, test); r.Success {
+			return pass("%s (passed on attempt %d)", r.Message, attempt)
+		}
+		if attempt == retries+1 {
+			r.Message += fmt.Sprintf("\nfailed all %d attempts", attempt)
+		}
+	}
+	return r
+}
@@ -0,0 +1,95 @@
+package trial
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTrial_Retry(t *testing.T) {
+	// flaky fails the first n calls
+	flaky := func(n int, panics bool) TestFunc {
+		calls := 0
+		return func(args ...interface{}) (interface{}, error) {
+			calls++
+			if calls <= n && panics {
+				panic("flaky")
+			}
+			if calls <= n {
+				return nil, errors.New("unavailable")
+			}
+			return calls, nil
+		}
+	}
+	cases := map[string]struct {
+		trial   *Trial
+		Case    Case
+		success bool
+		message string
+	}{
+		"no retry": {
+			trial:   New(flaky(1, false), nil),
+			Case:    Case{Expected: 2},
+			message: `FAIL: "no retry" unexpected error 'unavailable'`,
+		},
+		"passes on retry": {
+			trial:   New(flaky(2, false), nil),
+			Case:    Case{Expected: 3, Retry: 2},
+			success: true,
+			message: `PASS: "passes on retry" (passed on attempt 3)`,
+		},
+		"all attempts fail": {
+			trial:   New(flaky(5, false), nil),
+			Case:    Case{Expected: 3, Retry: 2},
+			message: "FAIL: \"all attempts fail\" unexpected error 'unavailable'\nfailed all 3 attempts",
+		},
+		"panics are retried": {
+			trial:   New(flaky(1, true), nil),
+			Case:    Case{Expected: 2, Retry: 1},
+			success: true,
+		},
+		"trial default": {
+			trial:   New(flaky(1, false), nil).Retry(1),
+			Case:    Case{Expected: 2},
+			success: true,
+		},
+		"case overrides trial": {
+			trial:   New(flaky(2, false), nil).Retry(1),
+			Case:    Case{Expected: 3, Retry: 2},
+			success: true,
+		},
+	}
+	for msg, test := range cases {
+		r := test.trial.retryCase(msg, test.Case)
+		if r.Success != test.success || !strings.HasPrefix(r.Message, test.message) {
+			t.Errorf("FAIL: %q %s", msg, r.Message)
+		}
+	}
+}
+
+func TestTrial_RetrySequential(t *testing.T) {
+	var count, calls int
+	fn := func(args ...interface{}) (interface{}, error) {
+		calls++
+		count += args[0].(int)
+		if calls == 2 {
+			// undo the increment so the retry sees the same state
+			count -= args[0].(int)
+			return -1, nil
+		}
+		return count, nil
+	}
+	inc := func(n int) func(interface{}) interface{} {
+		return func(prev interface{}) interface{} {
+			if prev == nil {
+				return n
+			}
+			return prev.(int) + n
+		}
+	}
+	New(fn, Cases{
+		"1 first": {Input: 1, Expected: inc(1)},
+		"2 flaky": {Input: 2, Expected: inc(2), Retry: 1},
+		"3 third": {Input: 3, Expected: inc(3)},
+	}).Sequential().Test(t)
+}
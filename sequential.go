@@ -0,0 +1,25 @@
+package trial
+
+// Sequential runs the cases in order of their names (even when Unordered) and
+// allows an Expected value to be calculated from the result of the previous case.
+// An Expected func(prev interface{}) interface{} is called with the previous
+// case's result (nil for the first case) to get the expected value.
+// This is used to test incremental state such as a state machine or counter
+func (t *Trial) Sequential() *Trial {
+	t.sequential = true
+	return t
+}
+
+// expectedFromPrev resolves an Expected func using the previous result of the trial
+func (t *Trial) expectedFromPrev(msg string, test *Case) *result {
+	fn, ok := test.Expected.(func(prev interface{}) interface{})
+	if !ok {
+		return nil
+	}
+	if !t.sequential {
+		r := fail("FAIL: %q Expected func requires Sequential", msg)
+		return &r
+	}
+	test.Expected = fn(t.prev)
+	return nil
+}
@@ -0,0 +1,62 @@
+package trial
+
+import (
+	"testing"
+)
+
+func TestTrial_Sequential(t *testing.T) {
+	var count int
+	fn := func(args ...interface{}) (interface{}, error) {
+		count += args[0].(int)
+		return count, nil
+	}
+	inc := func(n int) func(interface{}) interface{} {
+		return func(prev interface{}) interface{} {
+			if prev == nil {
+				return n
+			}
+			return prev.(int) + n
+		}
+	}
+	New(fn, Cases{
+		"1 first":  {Input: 2, Expected: inc(2)},
+		"2 second": {Input: 3, Expected: inc(3)},
+		"3 third":  {Input: 1, Expected: inc(1)},
+		"4 fixed":  {Input: 4, Expected: 10},
+	}).Sequential().Test(t)
+
+	tr := New(fn, Cases{"b": {}, "a": {}, "c": {}}).Unordered().Sequential()
+	if eq, diff := Equal(tr.caseNames(), []string{"a", "b", "c"}); !eq {
+		t.Error("FAIL: case order", diff)
+	}
+
+	r := New(fn, nil).testCase("unordered", Case{Input: 1, Expected: inc(1)})
+	if eq, diff := Equal(r.Message, `FAIL: "unordered" Expected func requires Sequential`); !eq {
+		t.Error("FAIL:", diff)
+	}
+}
+
+func TestTrial_SequentialRerun(t *testing.T) {
+	var count int
+	fn := func(args ...interface{}) (interface{}, error) {
+		count++
+		return count, nil
+	}
+	first := func(prev interface{}) interface{} {
+		if prev != nil {
+			return "first case should get a nil prev"
+		}
+		return count + 1
+	}
+	tr := New(fn, Cases{
+		"1 first":  {Expected: first},
+		"2 second": {Expected: func(prev interface{}) interface{} { return prev.(int) + 1 }},
+	}).Sequential()
+	tr.Run()
+	for _, r := range tr.Run() {
+		if !r.Success {
+			t.Error("FAIL:", r.Message)
+		}
+	}
+	tr.Test(t)
+}
@@ -0,0 +1,42 @@
+package trial
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// pkgPath is the import path of trial so its frames are removed from panic
+// stacks even when it's vendored or forked under another path
+var pkgPath = reflect.TypeOf(Trial{}).PkgPath()
+
+var stack struct {
+	mu      sync.Mutex
+	filters []string
+}
+
+// StackFilter removes the lines of panic stack traces that contain any
+// of the patterns, eg the path of helper packages that add noise.
+// The frames of trial and the runtime are always removed
+func StackFilter(patterns ...string) {
+	stack.mu.Lock()
+	stack.filters = append(stack.filters, patterns...)
+	stack.mu.Unlock()
+}
+
+// stackFilters returns a copy of the patterns added with StackFilter
+func stackFilters() []string {
+	stack.mu.Lock()
+	defer stack.mu.Unlock()
+	return append([]string(nil), stack.filters...)
+}
+
+// hasPattern checks if s contains any of the patterns
+func hasPattern(s string, patterns []string) bool {
+	for _, p := range patterns {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package trial
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStackFilter(t *testing.T) {
+	if pkgPath != "github.com/jbsmith7741/trial" {
+		t.Errorf("FAIL: package path %q", pkgPath)
+	}
+	if s := cleanStack(); !strings.Contains(s, "testing.tRunner") {
+		t.Fatalf("FAIL: stack should include the test runner\n%s", s)
+	}
+
+	defer func(filters []string) { stack.filters = filters }(stackFilters())
+	StackFilter("testing.tRunner", "src/testing/")
+	s := cleanStack()
+	if strings.Contains(s, "testing.tRunner") || strings.Contains(s, "src/testing/") {
+		t.Errorf("FAIL: filtered lines in stack\n%s", s)
+	}
+	if !strings.Contains(s, "TestStackFilter") {
+		t.Errorf("FAIL: unfiltered lines removed\n%s", s)
+	}
+}
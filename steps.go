@@ -0,0 +1,64 @@
+package trial
+
+import (
+	"sync/atomic"
+)
+
+// CountSteps passes a new *Steps counter to the TestFunc for each case
+// as the first argument (after any SharedFixture and WithRand values).
+// The code being tested records its steps (iterations, recursive calls)
+// and Case.MaxSteps fails the case if the budget is exceeded.
+// Use StepCounter(args...) to retrieve the counter within the TestFunc.
+func (t *Trial) CountSteps() *Trial {
+	t.countSteps = true
+	return t
+}
+
+// Steps counts the steps taken by an algorithm.
+// A nil *Steps can be safely used and records nothing
+type Steps struct {
+	n int64
+}
+
+// Step records a single step
+func (s *Steps) Step() {
+	s.Add(1)
+}
+
+// Add records n steps
+func (s *Steps) Add(n int) {
+	if s != nil {
+		atomic.AddInt64(&s.n, int64(n))
+	}
+}
+
+// Count of all recorded steps
+func (s *Steps) Count() int {
+	if s == nil {
+		return 0
+	}
+	return int(atomic.LoadInt64(&s.n))
+}
+
+// StepCounter returns the *Steps injected by CountSteps from the args
+// of a TestFunc, nil is returned if no counter was injected.
+func StepCounter(args ...interface{}) *Steps {
+	for _, arg := range args {
+		if s, ok := arg.(*Steps); ok {
+			return s
+		}
+	}
+	return nil
+}
+
+// checkSteps verifies the steps recorded stay within the budget
+func checkSteps(msg string, max int, args []interface{}) result {
+	s := StepCounter(args...)
+	if s == nil {
+		return fail("FAIL: %q MaxSteps requires CountSteps", msg)
+	}
+	if s.Count() > max {
+		return fail("FAIL: %q took %d steps, expected at most %d", msg, s.Count(), max)
+	}
+	return pass("PASS: %q", msg)
+}
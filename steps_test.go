@@ -0,0 +1,43 @@
+package trial
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestTrial_CountSteps(t *testing.T) {
+	// binary search that records each iteration
+	search := func(steps *Steps, values []int, v int) int {
+		lo, hi := 0, len(values)
+		for lo < hi {
+			steps.Step()
+			mid := (lo + hi) / 2
+			if values[mid] < v {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		return lo
+	}
+	values := make([]int, 1024)
+	for i := range values {
+		values[i] = i * 2
+	}
+	sort.Ints(values)
+	fn := func(args ...interface{}) (interface{}, error) {
+		return search(StepCounter(args...), values, args[1].(int)), nil
+	}
+	tr := New(fn, nil).CountSteps()
+
+	if r := tr.testCase("log n", Case{Input: 100, Expected: 50, MaxSteps: 11}); !r.Success {
+		t.Errorf("FAIL: %s", r.Message)
+	}
+	r := tr.testCase("budget exceeded", Case{Input: 100, Expected: 50, MaxSteps: 5})
+	if eq, diff := Equal(r.Message, `FAIL: "budget exceeded" took 10 steps, expected at most 5`); !eq {
+		t.Error("FAIL:", diff)
+	}
+	if r := New(fn, nil).testCase("no counter", Case{Input: Args(nil, 100), Expected: 50, MaxSteps: 5}); r.Success {
+		t.Error("FAIL: MaxSteps without CountSteps should fail")
+	}
+}
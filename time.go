@@ -0,0 +1,125 @@
+package trial
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// EqualTime compares time values as equal if they are within the tolerance.
+// This includes times nested in structs, slices and maps. Monotonic clock
+// readings are removed before comparing. Each time outside of the tolerance
+// is reported with its path and the delta. Other values are compared like Equal
+// and their diff is shown before the time deltas
+func EqualTime(tolerance time.Duration) CompareFunc {
+	return func(actual, expected interface{}) (bool, string) {
+		d := NewDiff()
+		seen := make(map[string]bool)
+		errorf := func(p cmp.Path, s string) {
+			if s = pathName(p) + s; !seen[s] {
+				seen[s] = true
+				d.Errorf("%s", s)
+			}
+		}
+		// times are compared by the filter and always ignored by cmp,
+		// so cmp only reports differences in other values
+		within := cmp.FilterPath(func(p cmp.Path) bool {
+			vx, vy := p.Last().Values()
+			if !vx.IsValid() || !vy.IsValid() || vx.Type() != vy.Type() || !vx.CanInterface() || !vy.CanInterface() {
+				return false
+			}
+			// skip the pairs of different indexes cmp compares to align slices
+			if si, ok := p.Last().(cmp.SliceIndex); ok {
+				if ix, iy := si.SplitKeys(); ix != iy {
+					return vx.Type() == timeType
+				}
+			}
+			switch {
+			case vx.Type() == timeType:
+				if s := timeDiff(vx.Interface().(time.Time), vy.Interface().(time.Time), tolerance); s != "" {
+					errorf(p, s)
+				}
+				return true
+			case isList(vx) && vx.Type().Elem() == timeType && vx.Len() != vy.Len():
+				errorf(p, fmt.Sprintf("length %d, expected %d", vx.Len(), vy.Len()))
+				for i := 0; i < vx.Len() && i < vy.Len(); i++ {
+					if s := timeDiff(vx.Index(i).Interface().(time.Time), vy.Index(i).Interface().(time.Time), tolerance); s != "" {
+						errorf(p, fmt.Sprintf("[%d] %s", i, s))
+					}
+				}
+				return true
+			}
+			return false
+		}, cmp.Ignore())
+		eq, diff := equal(actual, expected, within)
+		switch {
+		case eq && d.Empty():
+			return true, ""
+		case eq:
+			return false, d.String()
+		}
+		return false, strings.TrimRight(diff+"\n"+d.String(), "\n")
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// timeDiff describes the difference between two times when it is greater than the tolerance
+func timeDiff(actual, expected time.Time, tolerance time.Duration) string {
+	// remove monotonic clock readings
+	actual, expected = actual.Round(0), expected.Round(0)
+	delta := actual.Sub(expected)
+	if delta <= tolerance && delta >= -tolerance {
+		return ""
+	}
+	return fmt.Sprintf("%s, expected %s (delta %v)", actual.Format(time.RFC3339Nano), expected.Format(time.RFC3339Nano), delta)
+}
+
+// durationToken matches duration strings as parsed by time.ParseDuration
+var durationToken = regexp.MustCompile(`-?\b(?:\d+(?:\.\d+)?(?:ns|us|µs|ms|h|m|s))+\b`)
+
+// EqualFormattedDuration compares strings containing durations. Each duration
+// is parsed and compared numerically within the tolerance so formatting
+// differences like "1h0m0s" and "60m" are equal. All other text must match exactly.
+// Values that are not strings are compared with Equal
+func EqualFormattedDuration(tolerance time.Duration) CompareFunc {
+	return func(actual, expected interface{}) (bool, string) {
+		a, ok := actual.(string)
+		e, ok2 := expected.(string)
+		if !ok || !ok2 {
+			return Equal(actual, expected)
+		}
+		aText, aDur := splitDurations(a)
+		eText, eDur := splitDurations(e)
+		if aText != eText || len(aDur) != len(eDur) {
+			return Equal(a, e)
+		}
+		d := NewDiff()
+		for i := range aDur {
+			delta := aDur[i] - eDur[i]
+			if delta > tolerance || delta < -tolerance {
+				d.Errorf("duration[%d] %v, expected %v (delta %v)", i, aDur[i], eDur[i], delta)
+			}
+		}
+		return d.Empty(), d.String()
+	}
+}
+
+// splitDurations replaces each duration in s with a placeholder and returns
+// the remaining text along with the parsed durations
+func splitDurations(s string) (string, []time.Duration) {
+	durations := make([]time.Duration, 0)
+	text := durationToken.ReplaceAllStringFunc(s, func(tok string) string {
+		d, err := time.ParseDuration(tok)
+		if err != nil {
+			return tok
+		}
+		durations = append(durations, d)
+		return "{{duration}}"
+	})
+	return text, durations
+}
@@ -0,0 +1,133 @@
+package trial
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEqualTime(t *testing.T) {
+	type event struct {
+		Name string
+		At   time.Time
+		Log  map[string][]time.Time
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		b, s := EqualTime(time.Second)(args[0], args[1])
+		var err error
+		if s != "" {
+			err = errors.New(s)
+		}
+		return b, err
+	}
+	t1 := TimeHour("2020-01-01T00")
+	now := time.Now()
+	New(fn, Cases{
+		"within tolerance": {
+			Input:    Args(t1.Add(500*time.Millisecond), t1),
+			Expected: true,
+		},
+		"outside tolerance": {
+			Input:       Args(t1.Add(-2*time.Second), t1),
+			ExpectedErr: errors.New("2019-12-31T23:59:58Z, expected 2020-01-01T00:00:00Z (delta -2s)"),
+		},
+		"sub nanosecond layout": {
+			Input:       Args(t1.Add(1500*time.Millisecond+time.Nanosecond), t1),
+			ExpectedErr: errors.New("2020-01-01T00:00:01.500000001Z, expected 2020-01-01T00:00:00Z (delta 1.500000001s)"),
+		},
+		"monotonic reading": {
+			Input:    Args(now, now.Round(0)),
+			Expected: true,
+		},
+		"different locations": {
+			Input:    Args(t1.In(time.FixedZone("EST", -5*3600)), t1),
+			Expected: true,
+		},
+		"slice within tolerance": {
+			Input:    Args([]time.Time{t1, t1.Add(time.Hour + time.Second)}, []time.Time{t1, t1.Add(time.Hour)}),
+			Expected: true,
+		},
+		"slice index outside tolerance": {
+			Input:       Args([]time.Time{t1, t1.Add(time.Minute), t1}, []time.Time{t1, t1, t1.Add(time.Hour)}),
+			ExpectedErr: errors.New("[1]: 2020-01-01T00:01:00Z, expected 2020-01-01T00:00:00Z (delta 1m0s)\n[2]: "),
+		},
+		"slice length": {
+			Input:       Args([]time.Time{t1}, []time.Time{t1, t1}),
+			ExpectedErr: errors.New("length 1, expected 2"),
+		},
+		"nested times": {
+			Input: Args(
+				event{Name: "a", At: t1.Add(time.Millisecond), Log: map[string][]time.Time{"x": {t1}}},
+				event{Name: "a", At: t1, Log: map[string][]time.Time{"x": {t1.Add(-time.Millisecond)}}},
+			),
+			Expected: true,
+		},
+		"nested time outside tolerance": {
+			Input: Args(
+				event{At: t1, Log: map[string][]time.Time{"x": {t1}}},
+				event{At: t1, Log: map[string][]time.Time{"x": {t1.Add(time.Hour)}}},
+			),
+			ExpectedErr: errors.New(`Log["x"][0]: 2020-01-01T00:00:00Z, expected 2020-01-01T01:00:00Z (delta -1h0m0s)`),
+		},
+		"nested non time field": {
+			Input:       Args(event{Name: "a", At: t1}, event{Name: "b", At: t1}),
+			ExpectedErr: errors.New("Name:"),
+		},
+		"non time values": {
+			Input:    Args(1, 1),
+			Expected: true,
+		},
+	}).Test(t)
+
+	message := func(args ...interface{}) (interface{}, error) {
+		_, s := EqualTime(time.Second)(args[0], args[1])
+		return s, nil
+	}
+	New(message, Cases{
+		"only time deltas": {
+			Input:    Args(t1.Add(time.Hour), t1),
+			Expected: "2020-01-01T01:00:00Z, expected 2020-01-01T00:00:00Z (delta 1h0m0s)",
+		},
+		"slice length": {
+			Input:    Args([]time.Time{t1.Add(time.Hour)}, []time.Time{t1, t1}),
+			Expected: "length 1, expected 2\n[0] 2020-01-01T01:00:00Z, expected 2020-01-01T00:00:00Z (delta 1h0m0s)",
+		},
+	}).Test(t)
+}
+
+func TestEqualFormattedDuration(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		b, s := EqualFormattedDuration(time.Second)(args[0], args[1])
+		var err error
+		if s != "" {
+			err = errors.New(s)
+		}
+		return b, err
+	}
+	New(fn, Cases{
+		"different formatting": {
+			Input:    Args("took 1h0m0s", "took 60m"),
+			Expected: true,
+		},
+		"within tolerance": {
+			Input:    Args("elapsed 1.5s of 2m", "elapsed 1s of 120s"),
+			Expected: true,
+		},
+		"outside tolerance": {
+			Input:       Args("took 1h0m0s", "took 59m"),
+			ExpectedErr: errors.New("duration[0] 1h0m0s, expected 59m0s (delta 1m0s)"),
+		},
+		"different text": {
+			Input:       Args("took 1h", "waited 1h"),
+			ExpectedErr: errors.New("took"),
+		},
+		"words are not durations": {
+			Input:    Args("5 mins", "5 mins"),
+			Expected: true,
+		},
+		"non string values": {
+			Input:    Args(time.Minute, time.Minute),
+			Expected: true,
+		},
+	}).Test(t)
+}
@@ -0,0 +1,81 @@
+package trial
+
+import (
+	"time"
+)
+
+// MaxDuration limits the time all cases of the trial can take to run.
+// The case running when d is exceeded fails and the remaining cases are skipped,
+// this catches a single case that balloons the total runtime.
+func (t *Trial) MaxDuration(d time.Duration) *Trial {
+	t.maxDuration = d
+	return t
+}
+
+// startDeadline sets the time all cases must be completed by
+func (t *Trial) startDeadline() {
+	if t.maxDuration > 0 {
+		t.deadline = time.Now().Add(t.maxDuration)
+	}
+}
+
+// expired checks if the trial's max duration has been exceeded
+func (t *Trial) expired() bool {
+	return !t.deadline.IsZero() && !time.Now().Before(t.deadline)
+}
+
+// caseTimeout returns the timeout of a case limited by the trial's deadline,
+// limited is true when the deadline is sooner than the case's Timeout
+func (t *Trial) caseTimeout(test Case) (timeout time.Duration, limited bool) {
+	if t.deadline.IsZero() {
+		return test.Timeout, false
+	}
+	remaining := time.Until(t.deadline)
+	if remaining <= 0 {
+		remaining = time.Nanosecond
+	}
+	if test.Timeout > 0 && test.Timeout <= remaining {
+		return test.Timeout, false
+	}
+	return remaining, true
+}
+
+type callResult struct {
+	result   interface{}
+	err      error
+	panicked bool
+	rec      interface{}
+}
+
+// call runs the TestFunc fn with args. When timeout is set the TestFunc runs in
+// its own goroutine and timedOut is true if it doesn't return in time.
+// A panic in the goroutine is recovered and raised again in the caller
+// so a TestFunc that panics after timing out doesn't crash the test binary
+func call(fn TestFunc, args []interface{}, timeout time.Duration) (result interface{}, err error, timedOut bool) {
+	if timeout <= 0 {
+		result, err = fn(args...)
+		return result, err, false
+	}
+	// buffered so the goroutine can always finish
+	done := make(chan callResult, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				done <- callResult{panicked: true, rec: rec}
+			}
+		}()
+		r, err := fn(args...)
+		done <- callResult{result: r, err: err}
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case c := <-done:
+		if c.panicked {
+			panic(c.rec)
+		}
+		return c.result, c.err, false
+	case <-timer.C:
+		return nil, nil, true
+	}
+}
@@ -0,0 +1,90 @@
+package trial
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCase_Timeout(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		d := args[0].(time.Duration)
+		time.Sleep(d)
+		if d < 0 {
+			panic("negative duration")
+		}
+		return d, nil
+	}
+	tr := New(fn, nil)
+	cases := map[string]struct {
+		Case    Case
+		success bool
+		message string
+	}{
+		"no timeout": {
+			Case:    Case{Input: time.Millisecond, Expected: time.Millisecond},
+			success: true,
+		},
+		"within timeout": {
+			Case:    Case{Input: time.Millisecond, Expected: time.Millisecond, Timeout: time.Second},
+			success: true,
+		},
+		"timed out": {
+			Case:    Case{Input: time.Second, Expected: time.Second, Timeout: 10 * time.Millisecond},
+			message: `FAIL: "timed out" timed out after 10ms`,
+		},
+		"timed out expecting a panic": {
+			Case:    Case{Input: time.Second, ShouldPanic: true, Timeout: 10 * time.Millisecond},
+			message: `FAIL: "timed out expecting a panic" timed out after 10ms`,
+		},
+		"panic with timeout": {
+			Case:    Case{Input: -time.Millisecond, ShouldPanic: true, Timeout: time.Second},
+			success: true,
+		},
+		"unexpected panic with timeout": {
+			Case:    Case{Input: -time.Millisecond, Timeout: time.Second},
+			message: `PANIC: "unexpected panic with timeout" negative duration`,
+		},
+	}
+	for msg, test := range cases {
+		r := tr.testCase(msg, test.Case)
+		if r.Success != test.success || !strings.HasPrefix(r.Message, test.message) {
+			t.Errorf("FAIL: %q %s", msg, r.Message)
+		}
+	}
+}
+
+func TestTrial_MaxDuration(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		time.Sleep(args[0].(time.Duration))
+		return nil, nil
+	}
+	results := New(fn, Cases{
+		"a fast":     {Input: time.Duration(0)},
+		"b slow":     {Input: time.Second},
+		"c not run":  {Input: time.Duration(0)},
+		"d case cap": {Input: time.Duration(0), Timeout: time.Hour},
+	}).MaxDuration(20 * time.Millisecond).Run()
+
+	expected := []string{
+		`PASS: "a fast"`,
+		`FAIL: "b slow" exceeded the trial's max duration of 20ms`,
+		`SKIP: "c not run" trial exceeded max duration of 20ms`,
+		`SKIP: "d case cap" trial exceeded max duration of 20ms`,
+	}
+	messages := make([]string, len(results))
+	for i, r := range results {
+		messages[i] = r.Message
+	}
+	if eq, diff := Equal(messages, expected); !eq {
+		t.Error("FAIL: max duration", diff)
+	}
+
+	// the case Timeout is used when it's sooner than the deadline
+	tr := New(fn, nil).MaxDuration(time.Hour)
+	tr.startDeadline()
+	r := tr.testCase("case timeout", Case{Input: time.Second, Timeout: 10 * time.Millisecond})
+	if r.Message != `FAIL: "case timeout" timed out after 10ms` {
+		t.Errorf("FAIL: %s", r.Message)
+	}
+}
@@ -0,0 +1,98 @@
+package trial
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// TreeDiff displays differences as an indented tree that mirrors the
+// structure of the values compared. Changed values are marked with a *.
+// This replaces any Reporter or DerefPointers set on the trial.
+// Only used with the default Equal comparer.
+func (t *Trial) TreeDiff() *Trial {
+	return t.Reporter(func() DiffReporter { return &treeReporter{} })
+}
+
+// treeReporter is a DiffReporter that collects the differences into a tree
+type treeReporter struct {
+	path cmp.Path
+	root *treeNode
+}
+
+func (r *treeReporter) PushStep(ps cmp.PathStep) {
+	if r.root == nil {
+		r.root = &treeNode{label: fmt.Sprint(ps.Type())}
+	}
+	r.path = append(r.path, ps)
+}
+
+func (r *treeReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *treeReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	node := r.root
+	for _, step := range r.path[1:] {
+		switch step.(type) {
+		case cmp.StructField, cmp.SliceIndex, cmp.MapIndex:
+			node = node.child(step.String())
+		}
+	}
+	vx, vy := r.path.Last().Values()
+	node.changed = fmt.Sprintf("%s, expected: %s", treeValue(vx.IsValid(), vx), treeValue(vy.IsValid(), vy))
+}
+
+func (r *treeReporter) String() string {
+	if r.root == nil {
+		return ""
+	}
+	return r.root.String()
+}
+
+func treeValue(valid bool, v interface{}) string {
+	if !valid {
+		return "<missing>"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// treeNode is a step in the path to a changed value
+type treeNode struct {
+	label    string
+	changed  string
+	children []*treeNode
+}
+
+// child gets or creates the child node with label
+func (n *treeNode) child(label string) *treeNode {
+	for _, c := range n.children {
+		if c.label == label {
+			return c
+		}
+	}
+	c := &treeNode{label: label}
+	n.children = append(n.children, c)
+	return c
+}
+
+func (n *treeNode) String() string {
+	var lines []string
+	n.render(0, &lines)
+	return strings.Join(lines, "\n")
+}
+
+func (n *treeNode) render(depth int, lines *[]string) {
+	ln := strings.Repeat("  ", depth) + n.label
+	if n.changed != "" {
+		ln += "* " + n.changed
+	}
+	*lines = append(*lines, ln)
+	for _, c := range n.children {
+		c.render(depth+1, lines)
+	}
+}
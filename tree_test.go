@@ -0,0 +1,66 @@
+package trial
+
+import (
+	"testing"
+)
+
+func TestTreeEqual(t *testing.T) {
+	type address struct {
+		City string
+		Zip  int
+	}
+	type person struct {
+		Name    string
+		Address address
+		Tags    []string
+		Scores  map[string]int
+		age     int
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		_, s := reportEqual(args[0], args[1], &treeReporter{})
+		return s, nil
+	}
+	New(fn, Cases{
+		"equal": {
+			Input:    Args(person{Name: "a"}, person{Name: "a"}),
+			Expected: "",
+		},
+		"single value": {
+			Input:    Args(5, 10),
+			Expected: "int* 5, expected: 10",
+		},
+		"nested fields": {
+			Input: Args(
+				person{Name: "a", Address: address{City: "x", Zip: 1}, age: 3},
+				person{Name: "a", Address: address{City: "y", Zip: 2}, age: 4},
+			),
+			Expected: "trial.person\n" +
+				"  .Address\n" +
+				"    .City* x, expected: y\n" +
+				"    .Zip* 1, expected: 2\n" +
+				"  .age* 3, expected: 4",
+		},
+		"slices and maps": {
+			Input: Args(
+				person{Tags: []string{"a", "b"}, Scores: map[string]int{"x": 1}},
+				person{Tags: []string{"a", "c"}, Scores: map[string]int{"x": 2}},
+			),
+			Expected: "trial.person\n" +
+				"  .Tags\n" +
+				"    [1]* b, expected: c\n" +
+				"  .Scores\n" +
+				`    ["x"]* 1, expected: 2`,
+		},
+		"missing map key": {
+			Input:    Args(map[string]int{"a": 1}, map[string]int{"a": 1, "b": 2}),
+			Expected: "map[string]int\n" + `  ["b"]* <missing>, expected: 2`,
+		},
+	}).Test(t)
+
+	r := New(func(args ...interface{}) (interface{}, error) {
+		return address{City: "x"}, nil
+	}, nil).TreeDiff().testCase("tree diff", Case{Expected: address{City: "y"}})
+	if eq, diff := Equal(r.Message, "FAIL: \"tree diff\" \ntrial.address\n  .City* x, expected: y"); !eq {
+		t.Error("FAIL: TreeDiff option", diff)
+	}
+}
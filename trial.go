@@ -1,11 +1,21 @@
 package trial
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
 	"reflect"
+	"regexp"
 	"runtime/debug"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 var localTest = false
@@ -24,11 +34,19 @@ type (
 )
 
 // Comparer interface is implemented by an object to check for equality
-// and show any differences found
+// and show any differences found.
+// An Expected value that implements Comparer is used instead of the
+// trial's CompareFunc
 type Comparer interface {
 	Equals(interface{}) (bool, string)
 }
 
+// Validator interface is implemented by a reusable check of a result.
+// An Expected value that implements Validator passes when Validate returns nil
+type Validator interface {
+	Validate(actual interface{}) error
+}
+
 /*
 Alternative
 	Equals(interface{}) bool
@@ -37,9 +55,41 @@ Alternative
 
 // Trial framework used to test different logical states
 type Trial struct {
-	cases   map[string]Case
-	testFn  TestFunc
-	equalFn CompareFunc
+	cases     map[string]Case
+	testFn    TestFunc
+	ctxFn     TestFuncCtx     // set by NewWithContext
+	cleanupFn TestFuncCleanup // set by NewWithCleanup
+	ctx       context.Context
+	equalFn   CompareFunc // nil uses Equal with cmpOpts
+	cmpOpts   []cmp.Option
+
+	requireAssert bool
+	approveDir    string
+	collector     MetricsCollector
+	fixture       *fixture
+	distinct      *results
+	seed          *int64
+	reporter      func() DiffReporter
+	diffFormatter func(actual, expected interface{}) string
+	project       func(interface{}) interface{}
+	countSteps    bool
+	junitPath     string
+	reportTo      io.Writer
+	sequential    bool
+	parallel      bool
+	unordered     bool
+	retry         int
+	setups        []func() error
+	teardowns     []func()
+	prev          interface{} // result of the previous Sequential case
+	allowed       []*regexp.Regexp
+	crossCheck    []CompareFunc
+	outcomes      outcomes
+	detectRaces   bool
+	color         *bool // nil detects if colors are supported
+	maxDuration   time.Duration
+	slow          *time.Duration // show the elapsed time of cases that take at least this long
+	deadline      time.Time      // all cases must complete by, set from maxDuration
 }
 
 // Cases made during the trial
@@ -52,8 +102,34 @@ type Case struct {
 
 	// testing conditions
 	ShouldErr   bool  // is an error expected
-	ExpectedErr error // the error that was expected (nil is no error expected)
+	ExpectedErr error // the error that was expected (nil or NoError is no error expected)
 	ShouldPanic bool  // is a panic expected
+
+	ExpectedPanic interface{} // the value the case should panic with (implies ShouldPanic)
+
+	ExpectedMetrics map[string]float64 // metrics recorded by the trial's MetricsCollector
+	Deterministic   int                // number of times the TestFunc is run to verify the result is the same
+	ExpectMutated   interface{}        // the expected value of Input after the TestFunc is called
+	MaxSteps        int                // the maximum steps recorded by the injected Steps counter (see CountSteps)
+	Timeout         time.Duration      // fail the case if the TestFunc doesn't return in time (0 waits forever)
+	Retry           int                // number of times a failing case is run again before it fails
+
+	Only       bool   // only run the cases with Only set
+	Skip       bool   // don't run the case
+	SkipReason string // why the case is skipped, also skips the case when set
+
+	Comparer CompareFunc     // overrides the comparer of the trial for this case
+	Context  context.Context // the context passed to a TestFuncCtx (see NewWithContext)
+
+	GOOS   []string // only run the case on these operating systems (runtime.GOOS)
+	GOARCH []string // only run the case on these architectures (runtime.GOARCH)
+}
+
+// hasAssertion checks if the case is expecting any outcome
+func (c Case) hasAssertion() bool {
+	return c.Expected != nil || c.ShouldErr || c.ExpectedErr != nil || c.ShouldPanic || c.ExpectedPanic != nil ||
+		c.ExpectedMetrics != nil || c.Deterministic > 1 || c.ExpectMutated != nil ||
+		c.MaxSteps > 0
 }
 
 // New trial for your code
@@ -62,9 +138,8 @@ func New(fn TestFunc, cases map[string]Case) *Trial {
 		cases = make(map[string]Case)
 	}
 	return &Trial{
-		cases:   cases,
-		testFn:  fn,
-		equalFn: Equal,
+		cases:  cases,
+		testFn: fn,
 	}
 }
 
@@ -83,19 +158,167 @@ func (t *Trial) Comparer(fn CompareFunc) *Trial {
 	return t
 }
 
-// SubTest runs all cases as individual subtests
+// RequireAssertions fails any case that doesn't assert anything.
+// A case without an Expected value, ShouldErr, ExpectedErr or ShouldPanic
+// only checks that no error or panic occurred
+func (t *Trial) RequireAssertions() *Trial {
+	t.requireAssert = true
+	return t
+}
+
+// TransformPath applies fn to the values found at the struct field path
+// (eg "Inner.Value") of both actual and expected before they are compared.
+// Transforms are only used by the default Equal comparer.
+func (t *Trial) TransformPath(path string, fn func(interface{}) interface{}) *Trial {
+	t.cmpOpts = append(t.cmpOpts, cmp.FilterPath(func(p cmp.Path) bool {
+		// only transform the field itself and not the transformed result
+		_, isField := p.Last().(cmp.StructField)
+		return isField && p.String() == path
+	}, cmp.Transformer("TransformPath", fn)))
+	return t
+}
+
+// IgnoreSync ignores the sync primitives (Mutex, RWMutex, Once, WaitGroup and Cond)
+// found anywhere in the values being compared, so structs protected by a lock
+// can be compared without zeroing it. Only used by the default Equal comparer.
+func (t *Trial) IgnoreSync() *Trial {
+	t.cmpOpts = append(t.cmpOpts, ignoreSync)
+	return t
+}
+
+// syncTypes are the sync primitives ignored by IgnoreSync
+var syncTypes = map[reflect.Type]bool{
+	reflect.TypeOf(sync.Mutex{}):     true,
+	reflect.TypeOf(sync.RWMutex{}):   true,
+	reflect.TypeOf(sync.Once{}):      true,
+	reflect.TypeOf(sync.WaitGroup{}): true,
+	reflect.TypeOf(sync.Cond{}):      true,
+}
+
+// ignoreSync ignores the sync primitives and pointers to them
+var ignoreSync = cmp.FilterPath(func(p cmp.Path) bool {
+	typ := p.Last().Type()
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return syncTypes[typ]
+}, cmp.Ignore())
+
+// DiffFormatter renders the differences shown when a result doesn't match the
+// Expected value with fn. The comparison is still done by the trial's comparer
+// so a custom display can be used with the default equality.
+func (t *Trial) DiffFormatter(fn func(actual, expected interface{}) string) *Trial {
+	t.diffFormatter = fn
+	return t
+}
+
+// Project applies fn to the result of each case before it's compared with
+// the Expected value. Unlike TransformPath only the actual result is changed,
+// so the Expected value should be the projected form (eg a single field or summary).
+func (t *Trial) Project(fn func(interface{}) interface{}) *Trial {
+	t.project = fn
+	return t
+}
+
+// compare actual and expected using the comparer of the trial
+func (t *Trial) compare(actual, expected interface{}) (bool, string) {
+	return t.compareWith(t.equalFn, actual, expected)
+}
+
+// compareCase compares actual and expected using the case's Comparer
+// or the comparer of the trial when it isn't set.
+// A panic in the comparer is reported as a difference
+func (t *Trial) compareCase(test Case, actual, expected interface{}) (equal bool, diff string) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			equal, diff = false, fmt.Sprintf("comparer panic: %v\n%s", rec, cleanStack())
+		}
+	}()
+	if test.Comparer == nil {
+		return t.compare(actual, expected)
+	}
+	return t.compareWith(test.Comparer, actual, expected)
+}
+
+// compareWith compares actual and expected using fn in place of the trial's comparer.
+// Expected values that are a Comparer or Validator are still used to check actual
+func (t *Trial) compareWith(fn CompareFunc, actual, expected interface{}) (bool, string) {
+	if a, ok := expected.(anyOf); ok {
+		return a.match(actual, func(x, y interface{}) (bool, string) {
+			return t.compareWith(fn, x, y)
+		})
+	}
+	if c, ok := expected.(Comparer); ok {
+		return c.Equals(actual)
+	}
+	if v, ok := expected.(Validator); ok {
+		if err := v.Validate(actual); err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}
+	if fn == nil && t.reporter != nil {
+		return reportEqual(actual, expected, t.reporter(), t.cmpOpts...)
+	}
+	if fn == nil {
+		return equal(actual, expected, t.cmpOpts...)
+	}
+	return fn(actual, expected)
+}
+
+// SubTest runs all cases as individual subtests.
+// When tst can't run subtests (eg *testing.B) the cases are run with Test
 func (t *Trial) SubTest(tst testing.TB) {
 	if h, ok := tst.(tHelper); ok {
 		h.Helper()
 	}
+	sub, ok := tst.(subTester)
+	if !ok {
+		t.Test(tst)
+		return
+	}
+	if !t.ready(tst) {
+		return
+	}
+	t.startDeadline()
+	t.prev = nil
+	t.outcomes.reset()
+	if t.runParallel() {
+		// parallel subtests run after SubTest returns
+		tst.Cleanup(func() {
+			t.fixture.teardown()
+			t.finish(tst)
+		})
+	} else {
+		defer t.fixture.teardown()
+		defer t.finish(tst)
+	}
 
-	for msg, test := range t.cases {
-		tst.(*testing.T).Run(msg, func(tb *testing.T) {
-			r := t.testCase(msg, test)
+	names, skipped := t.focus(t.caseNames())
+	if skipped > 0 {
+		tst.Logf("skipped %d cases without Only", skipped)
+	}
+	for _, msg := range names {
+		msg, test := msg, t.cases[msg]
+		if skipCase(msg) {
+			continue
+		}
+		sub.Run(msg, func(tb *testing.T) {
+			if t.runParallel() {
+				tb.Parallel()
+			}
+			r := t.runCase(tst, msg, test)
+			if r.skipped() {
+				tb.Skip(strings.TrimPrefix(r.Message, "SKIP: "))
+			}
+			if r.Success && r.Message != fmt.Sprintf("PASS: %q", msg) {
+				// show any additional details of passing cases
+				tb.Log(r.Message)
+			}
 			if !r.Success {
 				s := strings.Replace(r.Message, "\""+msg+"\"", "", 1)
 				s = strings.Replace(s, "FAIL:", "", 1)
-				tb.Error("\033[31m" + strings.TrimLeft(s, " \n") + "\033[39m")
+				tb.Error(t.red(strings.TrimLeft(s, " \n")))
 			}
 		})
 	}
@@ -106,60 +329,370 @@ func (t *Trial) Test(tst testing.TB) {
 	if h, ok := tst.(tHelper); ok {
 		h.Helper()
 	}
-	for msg, test := range t.cases {
-		r := t.testCase(msg, test)
+	if !t.ready(tst) {
+		return
+	}
+	for _, r := range t.run(tst) {
 		if r.Success {
 			tst.Log(r.Message)
 		} else {
-			tst.Error("\033[31m" + r.Message + "\033[39m")
+			tst.Error(t.red(r.Message))
+		}
+	}
+	t.finish(tst)
+}
+
+// Run all cases and return the result of each case in the order they were run
+// without reporting them, so the outcomes can be inspected by the caller.
+// Checks done after all cases (AssertAllDistinct, JUnit) and DetectRaces
+// are only done by Test and SubTest.
+func (t *Trial) Run() []Result {
+	if t.testFn == nil {
+		panic(errNilFunc)
+	}
+	return t.run(nil)
+}
+
+const errNilFunc = "trial: TestFunc is nil, pass the function being tested to New"
+
+// ready checks the trial can be run. A nil TestFunc fails the test immediately
+// and a trial without cases is logged since it passes without testing anything
+func (t *Trial) ready(tst testing.TB) bool {
+	if t.testFn == nil {
+		tst.Fatal(errNilFunc)
+		return false
+	}
+	if len(t.cases) == 0 {
+		tst.Log("trial: no cases to run")
+	}
+	return true
+}
+
+// run all cases one after another, tst is nil when called by Run
+func (t *Trial) run(tst testing.TB) []Result {
+	defer t.fixture.teardown()
+	t.startDeadline()
+	t.prev = nil
+	t.outcomes.reset()
+	names, skipped := t.focus(t.caseNames())
+	if skipped > 0 && tst != nil {
+		tst.Logf("skipped %d cases without Only", skipped)
+	}
+	results := make([]Result, 0, len(names))
+	for _, msg := range names {
+		test := t.cases[msg]
+		if skipCase(msg) {
+			continue
+		}
+		r, elapsed := t.timeCase(tst, msg, test)
+		results = append(results, Result{Name: msg, Success: r.Success, Message: r.Message, Duration: elapsed})
+	}
+	return results
+}
+
+// Unordered runs the cases in the random order of the cases map
+// instead of sorted by name
+func (t *Trial) Unordered() *Trial {
+	t.unordered = true
+	return t
+}
+
+// caseNames returns the names of the cases in the order they are run
+func (t *Trial) caseNames() []string {
+	names := make([]string, 0, len(t.cases))
+	for msg := range t.cases {
+		names = append(names, msg)
+	}
+	if !t.unordered || t.sequential {
+		sort.Strings(names)
+	}
+	return names
+}
+
+// caseFunc returns the TestFunc for a case and a func that is
+// called once the case has completed
+func (t *Trial) caseFunc(test Case) (TestFunc, func()) {
+	switch {
+	case t.ctxFn != nil:
+		return t.contextFunc(test)
+	case t.cleanupFn != nil:
+		return t.cleanupFunc()
+	}
+	return t.testFn, func() {}
+}
+
+// focus only keeps the names of cases with Only set when any case has it.
+// The number of names removed is returned
+func (t *Trial) focus(names []string) ([]string, int) {
+	only := make([]string, 0)
+	for _, msg := range names {
+		if t.cases[msg].Only {
+			only = append(only, msg)
+		}
+	}
+	if len(only) == 0 {
+		return names, 0
+	}
+	return only, len(names) - len(only)
+}
+
+// runCase runs a single case and records the outcome
+func (t *Trial) runCase(tst testing.TB, msg string, test Case) result {
+	r, _ := t.timeCase(tst, msg, test)
+	return r
+}
+
+// timeCase runs a single case, records the outcome and returns how long it took
+func (t *Trial) timeCase(tst testing.TB, msg string, test Case) (result, time.Duration) {
+	start := time.Now()
+	var r result
+	if t.isRaceCase() && tst != nil {
+		r = raceCase(tst.Name(), msg)
+	} else {
+		r = t.retryCase(msg, test)
+	}
+	elapsed := time.Since(start)
+	r = t.withElapsed(r, elapsed)
+	if t.recordOutcomes() {
+		t.outcomes.add(outcome{name: msg, result: r, duration: elapsed})
+	}
+	return r, elapsed
+}
+
+// finish runs the checks done after all cases have completed
+func (t *Trial) finish(tst testing.TB) {
+	if r := t.checkDistinct(); !r.Success {
+		tst.Error(t.red(r.Message))
+	}
+	if t.junitPath != "" {
+		if err := t.writeJUnit(tst.Name()); err != nil {
+			tst.Error(t.red(err.Error()))
+		}
+	}
+	if t.reportTo != nil {
+		if err := t.writeReport(); err != nil {
+			tst.Error(t.red(err.Error()))
 		}
 	}
 }
 
 func (t *Trial) testCase(msg string, test Case) (r result) {
-	var finished bool
+	if test.Skip || test.SkipReason != "" {
+		reason := test.SkipReason
+		if reason == "" {
+			reason = "skipped"
+		}
+		return skip("SKIP: %q %s", msg, reason)
+	}
+	if reason := test.platformSkip(); reason != "" {
+		return skip("SKIP: %q %s", msg, reason)
+	}
+	if t.expired() {
+		return skip("SKIP: %q trial exceeded max duration of %v", msg, t.maxDuration)
+	}
+	if t.requireAssert && !test.hasAssertion() {
+		return fail("FAIL: %q no assertion", msg)
+	}
+	if r := t.expectedFromPrev(msg, &test); r != nil {
+		return *r
+	}
+	// finished is set once the result is known and stopped when the
+	// TestFunc couldn't complete, so the case keeps its reason for failing
+	var finished, stopped bool
+	shouldPanic := test.ShouldPanic || test.ExpectedPanic != nil
 	defer func() {
 		rec := recover()
-		if rec == nil && test.ShouldPanic {
+		if rec == nil && shouldPanic && !stopped {
 			r = fail("FAIL: %q did not panic", msg)
-		} else if rec != nil && !test.ShouldPanic {
+		} else if rec != nil && !shouldPanic {
 			r = fail("PANIC: %q %v\n%s", msg, rec, cleanStack())
+		} else if rec != nil && test.ExpectedPanic != nil {
+			r = pass("PASS: %q", msg)
+			if equal, diff := t.compareCase(test, rec, test.ExpectedPanic); !equal {
+				r = fail("FAIL: %q panic %v does not match expected\n%s", msg, rec, diff)
+			}
 		} else if !finished {
 			r = pass("PASS: %q", msg)
 		}
 	}()
-	var err error
-	var result interface{}
-	if inputs, ok := test.Input.([]interface{}); ok {
-		result, err = t.testFn(inputs...)
-	} else {
-		result, err = t.testFn(test.Input)
+	defer t.teardown()
+	if err := t.setup(); err != nil {
+		finished, stopped = true, true
+		return fail("FAIL: %q setup failed: %v", msg, err)
+	}
+	if t.collector != nil {
+		t.collector.Reset()
+	}
+	lazy := test.Input
+	test.Input = inputValue(test.Input)
+	var before string
+	if test.ExpectMutated != nil {
+		before = fmt.Sprintf("%+v", test.Input)
+	}
+	fn, done := t.caseFunc(test)
+	defer done()
+	args := t.args(test.Input)
+	timeout, limited := t.caseTimeout(test)
+	result, err, timedOut := call(fn, args, timeout)
+	if timedOut && limited {
+		finished, stopped = true, true
+		return fail("FAIL: %q exceeded the trial's max duration of %v", msg, t.maxDuration)
+	}
+	if timedOut {
+		finished, stopped = true, true
+		return fail("FAIL: %q timed out after %v", msg, test.Timeout)
+	}
+	if t.sequential {
+		t.prev = result
+	}
+	test.ExpectedErr = expectedError(test.ExpectedErr, test.Input)
+	// only successful results are checked for distinct values
+	if t.distinct != nil && err == nil && !test.ShouldErr && test.ExpectedErr == nil && !shouldPanic {
+		t.distinct.add(msg, result)
+	}
+
+	r = t.checkResult(msg, test, result, err)
+	if r.Success && test.ExpectedMetrics != nil {
+		r = t.checkMetrics(msg, test.ExpectedMetrics)
+	}
+	if r.Success && test.MaxSteps > 0 {
+		r = checkSteps(msg, test.MaxSteps, args)
+	}
+	if r.Success && test.ExpectMutated != nil {
+		r = t.checkMutated(msg, test, before)
+	}
+	if r.Success && test.Deterministic > 1 {
+		// each run gets a fresh input when it's built lazily
+		test.Input = lazy
+		r = t.checkDeterministic(msg, test, fn, result, err)
+	}
+	finished = true
+	return r
+}
+
+// args converts the input of a case to the arguments of the TestFunc
+// Values injected by the trial are passed first in the order:
+// SharedFixture, WithRand, CountSteps
+func (t *Trial) args(input interface{}) []interface{} {
+	input = inputValue(input)
+	inputs, ok := input.([]interface{})
+	if !ok {
+		inputs = []interface{}{input}
+	}
+	args := make([]interface{}, 0, len(inputs)+3)
+	if t.fixture != nil {
+		args = append(args, t.fixture.get())
 	}
+	if t.seed != nil {
+		args = append(args, rand.New(rand.NewSource(*t.seed)))
+	}
+	if t.countSteps {
+		args = append(args, &Steps{})
+	}
+	return append(args, inputs...)
+}
+
+// inputValue calls an Input that is a func() interface{} or func() []interface{}
+// to build a fresh value for each run, any other Input is returned as is
+func inputValue(input interface{}) interface{} {
+	switch fn := input.(type) {
+	case func() interface{}:
+		return fn()
+	case func() []interface{}:
+		return fn()
+	}
+	return input
+}
 
+// checkResult verifies the result and error returned from the TestFunc
+// A panic while checking (eg in a Project func or DiffFormatter) fails the case
+func (t *Trial) checkResult(msg string, test Case, actual interface{}, err error) (r result) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			r = fail("FAIL: %q \ncomparer panic: %v\n%s", msg, rec, cleanStack())
+		}
+	}()
+	var argErr argsError
+	if errors.As(err, &argErr) {
+		return fail("FAIL: %q %v", msg, err)
+	}
 	if (test.ShouldErr && err == nil) || (test.ExpectedErr != nil && err == nil) {
-		finished = true
 		return fail("FAIL: %q should error", msg)
 	} else if !test.ShouldErr && err != nil && test.ExpectedErr == nil {
-		finished = true
 		return fail("FAIL: %q unexpected error '%s'", msg, err.Error())
+	} else if e, ok := test.ExpectedErr.(errEqual); ok {
+		if equal, diff := t.compareCase(test, err, e.target); !equal {
+			return fail("FAIL: %q error %q does not equal expected %q\n%s", msg, err, e.target, diff)
+		}
 	} else if test.ExpectedErr != nil && !isExpectedError(err, test.ExpectedErr) {
-		finished = true
-		return fail("FAIL: %q error %q does not match expected %q", msg, err, test.ExpectedErr)
-	} else if !test.ShouldErr && test.ExpectedErr == nil {
-		if equal, diff := t.equalFn(result, test.Expected); !equal {
-			finished = true
+		return fail("FAIL: %q error %q does not match expected %q%s", msg, err, test.ExpectedErr, errDetail(err, test.ExpectedErr))
+	} else if !test.ShouldErr && test.ExpectedErr == nil && (test.Expected != nil || test.ExpectMutated == nil) {
+		if t.project != nil {
+			actual = t.project(actual)
+		}
+		var equal bool
+		var diff string
+		if t.approveDir != "" {
+			equal, diff = t.approve(msg, actual)
+		} else {
+			equal, diff = t.compareCase(test, actual, test.Expected)
+			if len(t.crossCheck) > 0 {
+				if r := t.checkComparers(msg, actual, test.Expected, equal, diff); !r.Success {
+					return r
+				}
+			}
+		}
+		if !equal && t.isAllowedDiff(diff) {
+			return pass("PASS: %q with allowed differences\n%s", msg, diff)
+		}
+		if !equal && t.diffFormatter != nil {
+			return fail("FAIL: %q \n%s", msg, t.diffFormatter(actual, test.Expected))
+		}
+		if !equal {
 			return fail("FAIL: %q \n%s", msg, diff)
 		}
-		finished = true
-		return pass("PASS: %q", msg)
+	}
+	return pass("PASS: %q", msg)
+}
+
+// checkMutated compares the Input after the TestFunc was called with ExpectMutated
+func (t *Trial) checkMutated(msg string, test Case, before string) result {
+	if equal, diff := t.compareCase(test, test.Input, test.ExpectMutated); !equal {
+		return fail("FAIL: %q input %s mutated to %+v\n%s", msg, before, test.Input, diff)
+	}
+	return pass("PASS: %q", msg)
+}
+
+// checkDeterministic runs the TestFunc again to verify the same result and error are returned every time.
+// Each run has the timeout of the case and is compared with the case's comparer
+func (t *Trial) checkDeterministic(msg string, test Case, fn TestFunc, first interface{}, firstErr error) result {
+	for run := 2; run <= test.Deterministic; run++ {
+		timeout, limited := t.caseTimeout(test)
+		actual, err, timedOut := call(fn, t.args(test.Input), timeout)
+		if timedOut && limited {
+			return fail("FAIL: %q exceeded the trial's max duration of %v", msg, t.maxDuration)
+		}
+		if timedOut {
+			return fail("FAIL: %q run %d timed out after %v", msg, run, test.Timeout)
+		}
+		if fmt.Sprint(err) != fmt.Sprint(firstErr) {
+			return fail("FAIL: %q not deterministic, run %d error %v expected %v", msg, run, err, firstErr)
+		}
+		if equal, diff := t.compareCase(test, actual, first); !equal {
+			return fail("FAIL: %q not deterministic, run %d differs\n%s", msg, run, diff)
+		}
 	}
 	return pass("PASS: %q", msg)
 }
 
 // cleanStack removes unhelpful lines from a panic stack track
 func cleanStack() (s string) {
+	filters := stackFilters()
 	for _, ln := range strings.Split(string(debug.Stack()), "\n") {
-		if !localTest && strings.Contains(ln, "/jbsmith7741/trial") {
+		if !localTest && strings.Contains(ln, pkgPath) {
+			continue
+		}
+		if hasPattern(ln, filters) {
 			continue
 		}
 		if strings.Contains(ln, "go/src/runtime/debug/stack.go") {
@@ -174,8 +707,25 @@ func cleanStack() (s string) {
 }
 
 func isExpectedError(actual, expected error) bool {
-	if err, ok := expected.(errCheck); ok {
+	switch err := expected.(type) {
+	case errCheck:
 		return reflect.TypeOf(actual) == reflect.TypeOf(err.err)
+	case errChain:
+		found := false
+		walkErr(actual, 0, func(e error, _ int) {
+			found = found || errors.Is(e, err.target)
+		})
+		return found
+	case errIs:
+		return errors.Is(actual, err.target)
+	case errAs:
+		return errors.As(actual, reflect.New(err.typ).Interface())
+	case errMsg:
+		return strings.Contains(actual.Error(), string(err))
+	}
+	// sentinel errors are matched even when wrapped
+	if errors.Is(actual, expected) {
+		return true
 	}
 	return strings.Contains(actual.Error(), expected.Error())
 }
@@ -194,6 +744,15 @@ func ErrType(err error) error {
 	return errCheck{err}
 }
 
+// Result is the outcome of a case returned by Run
+type Result struct {
+	Name    string // the name of the case
+	Success bool   // the case passed or was skipped
+	Message string // the PASS, FAIL, PANIC or SKIP message of the case
+
+	Duration time.Duration // how long the case took to run
+}
+
 type result struct {
 	Success bool
 	Message string
@@ -206,6 +765,15 @@ func pass(format string, args ...interface{}) result {
 	}
 }
 
+// skip is a passing result for a case that was not run
+func skip(format string, args ...interface{}) result {
+	return pass(format, args...)
+}
+
+func (r result) skipped() bool {
+	return r.Success && strings.HasPrefix(r.Message, "SKIP:")
+}
+
 func fail(format string, args ...interface{}) result {
 	return result{
 		Success: false,
@@ -216,3 +784,8 @@ func fail(format string, args ...interface{}) result {
 type tHelper interface {
 	Helper()
 }
+
+// subTester runs f as a subtest, implemented by *testing.T
+type subTester interface {
+	Run(name string, f func(t *testing.T)) bool
+}
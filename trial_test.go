@@ -2,7 +2,13 @@ package trial
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -68,7 +74,7 @@ func TestTrial_TestCase(t *testing.T) {
 				Input:    []interface{}{10, 2},
 				Expected: 10,
 			},
-			expResult: result{false, "FAIL: \"10/2 - unexpected result\" \n{int}:\n\t-: 5\n\t+: 10\n"},
+			expResult: result{false, "FAIL: \"10/2 - unexpected result\" \n"},
 		},
 		"parse time": {
 			trial: New(panicFn, nil),
@@ -155,6 +161,409 @@ func TestTrial_TestCase(t *testing.T) {
 			},
 			expResult: result{false, `FAIL: "error type testErr with mismatch response"`},
 		},
+		"error template with args": {
+			trial:     New(divideFn, nil),
+			Case:      Case{Input: Args(7, 0), ExpectedErr: ErrTemplate("divide by zero")},
+			expResult: result{true, `PASS: "error template with args"`},
+		},
+		"error template with input": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("invalid value %v", args[0])
+			}, nil),
+			Case:      Case{Input: "abc", ExpectedErr: ErrTemplate("invalid value {{input}}")},
+			expResult: result{true, `PASS: "error template with input"`},
+		},
+		"error template with fmt verbs": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("%d is larger than %d", args[0], args[1])
+			}, nil),
+			Case:      Case{Input: Args(5, 3), ExpectedErr: ErrTemplatef("%d is larger than %d")},
+			expResult: result{true, `PASS: "error template with fmt verbs"`},
+		},
+		"error template with percent sign": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("disk %v is 100%% full", args[0])
+			}, nil),
+			Case:      Case{Input: "sda", ExpectedErr: ErrTemplate("disk {{input}} is 100% full")},
+			expResult: result{true, `PASS: "error template with percent sign"`},
+		},
+		"error template mismatch": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, errors.New("invalid value xyz")
+			}, nil),
+			Case:      Case{Input: "abc", ExpectedErr: ErrTemplate("invalid value {{input}}")},
+			expResult: result{false, `FAIL: "error template mismatch" error "invalid value xyz" does not match expected "invalid value abc"`},
+		},
+		"expected Comparer is used": {
+			trial:     New(divideFn, nil),
+			Case:      Case{Input: Args(6, 2), Expected: ConvertibleTo(float64(0))},
+			expResult: result{true, `PASS: "expected Comparer is used"`},
+		},
+		"expected Validator passes": {
+			trial:     New(divideFn, nil),
+			Case:      Case{Input: Args(6, 2), Expected: inRange{1, 5}},
+			expResult: result{true, `PASS: "expected Validator passes"`},
+		},
+		"expected Validator error": {
+			trial:     New(divideFn, nil),
+			Case:      Case{Input: Args(6, 1), Expected: inRange{1, 5}},
+			expResult: result{false, "FAIL: \"expected Validator error\" \n6 is not between 1 and 5"},
+		},
+		"error chain contains wrapped error": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("read: %w", io.EOF)
+			}, nil),
+			Case:      Case{ExpectedErr: ErrContains(io.EOF)},
+			expResult: result{true, `PASS: "error chain contains wrapped error"`},
+		},
+		"error chain contains joined error": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("close: %w", joinErr{errors.New("a"), io.ErrUnexpectedEOF})
+			}, nil),
+			Case:      Case{ExpectedErr: ErrContains(io.ErrUnexpectedEOF)},
+			expResult: result{true, `PASS: "error chain contains joined error"`},
+		},
+		"error chain missing error": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("close: %w", joinErr{errors.New("a"), errors.New("b")})
+			}, nil),
+			Case: Case{ExpectedErr: ErrContains(io.EOF)},
+			expResult: result{false, `FAIL: "error chain missing error" error "close: a; b" does not match expected "EOF"
+error chain:
+  *fmt.wrapError: close: a; b
+    trial.joinErr: a; b
+      *errors.errorString: a
+      *errors.errorString: b`},
+		},
+		"wrapped sentinel error": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("context: %w", errSentinel)
+			}, nil),
+			Case:      Case{ExpectedErr: errSentinel},
+			expResult: result{true, `PASS: "wrapped sentinel error"`},
+		},
+		"ErrIs wrapped error": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("context: %w", io.EOF)
+			}, nil),
+			Case:      Case{ExpectedErr: ErrIs(io.EOF)},
+			expResult: result{true, `PASS: "ErrIs wrapped error"`},
+		},
+		"ErrIs does not match message": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, errors.New("unexpected EOF")
+			}, nil),
+			Case:      Case{ExpectedErr: ErrIs(io.EOF)},
+			expResult: result{false, "FAIL: \"ErrIs does not match message\" error \"unexpected EOF\" does not match expected \"EOF\"\nerror chain:\n  *errors.errorString: unexpected EOF"},
+		},
+		"ErrAs wrapped error type": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("dial: %w", &strconv.NumError{Func: "Atoi", Num: "x", Err: strconv.ErrSyntax})
+			}, nil),
+			Case:      Case{ExpectedErr: ErrAs(&strconv.NumError{})},
+			expResult: result{true, `PASS: "ErrAs wrapped error type"`},
+		},
+		"ErrAs value error type": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("test: %w", testErr{})
+			}, nil),
+			Case:      Case{ExpectedErr: ErrAs(testErr{})},
+			expResult: result{true, `PASS: "ErrAs value error type"`},
+		},
+		"ErrAs interface": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("test: %w", testErr{})
+			}, nil),
+			Case:      Case{ExpectedErr: ErrAs((*fmt.Stringer)(nil))},
+			expResult: result{false, `FAIL: "ErrAs interface" error "test: " does not match expected "fmt.Stringer"`},
+		},
+		"ErrAs missing type": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, fmt.Errorf("read: %w", io.EOF)
+			}, nil),
+			Case:      Case{ExpectedErr: ErrAs(&strconv.NumError{})},
+			expResult: result{false, "FAIL: \"ErrAs missing type\" error \"read: EOF\" does not match expected \"*strconv.NumError\"\nerror chain:\n  *fmt.wrapError: read: EOF\n    *errors.errorString: EOF"},
+		},
+		"ErrEqual same fields": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, &fieldErr{Field: "name", Code: 2}
+			}, nil),
+			Case:      Case{ExpectedErr: ErrEqual(&fieldErr{Field: "name", Code: 2})},
+			expResult: result{true, `PASS: "ErrEqual same fields"`},
+		},
+		"ErrEqual different fields": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, &fieldErr{Field: "name", Code: 2}
+			}, nil),
+			Case:      Case{ExpectedErr: ErrEqual(&fieldErr{Field: "name", Code: 3})},
+			expResult: result{false, "FAIL: \"ErrEqual different fields\" error \"invalid name\" does not equal expected \"invalid name\"\n"},
+		},
+		"ErrEqual no error": {
+			trial:     New(func(args ...interface{}) (interface{}, error) { return nil, nil }, nil),
+			Case:      Case{ExpectedErr: ErrEqual(&fieldErr{Field: "name"})},
+			expResult: result{false, `FAIL: "ErrEqual no error" should error`},
+		},
+		"case comparer": {
+			trial:     New(func(args ...interface{}) (interface{}, error) { return "hello world", nil }, nil),
+			Case:      Case{Expected: "world", Comparer: Contains},
+			expResult: result{true, `PASS: "case comparer"`},
+		},
+		"case comparer overrides trial": {
+			trial:     New(func(args ...interface{}) (interface{}, error) { return "hello world", nil }, nil).Comparer(Contains),
+			Case:      Case{Expected: "world", Comparer: Equal},
+			expResult: result{false, `FAIL: "case comparer overrides trial"`},
+		},
+		"case comparer with AnyOf": {
+			trial:     New(func(args ...interface{}) (interface{}, error) { return "hello world", nil }, nil),
+			Case:      Case{Expected: AnyOf("moon", "hello"), Comparer: Contains},
+			expResult: result{true, `PASS: "case comparer with AnyOf"`},
+		},
+		"case comparer with ExpectMutated": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				args[0].(map[string]int)["b"] = 2
+				return nil, nil
+			}, nil),
+			Case:      Case{Input: map[string]int{"a": 1}, ExpectMutated: map[string]int{"b": 2}, Comparer: Contains},
+			expResult: result{true, `PASS: "case comparer with ExpectMutated"`},
+		},
+		"ErrMsgContains substring": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, errors.New("open config: permission denied")
+			}, nil),
+			Case:      Case{ExpectedErr: ErrMsgContains("permission denied")},
+			expResult: result{true, `PASS: "ErrMsgContains substring"`},
+		},
+		"ErrMsgContains different message": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, errors.New("open config: not found")
+			}, nil),
+			Case:      Case{ExpectedErr: ErrMsgContains("permission denied")},
+			expResult: result{false, `FAIL: "ErrMsgContains different message" error "open config: not found" does not match expected "permission denied"`},
+		},
+		"ErrMsgContains no error": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, nil
+			}, nil),
+			Case:      Case{ExpectedErr: ErrMsgContains("denied")},
+			expResult: result{false, `FAIL: "ErrMsgContains no error" should error`},
+		},
+		"comparer panic": {
+			trial: New(func(args ...interface{}) (interface{}, error) { return 1, nil }, nil).Comparer(func(actual, expected interface{}) (bool, string) {
+				return actual.(string) == expected, ""
+			}),
+			Case:      Case{Expected: "1"},
+			expResult: result{false, "FAIL: \"comparer panic\" \ncomparer panic: interface conversion: interface {} is int, not string"},
+		},
+		"comparer panic with ShouldPanic": {
+			trial: New(func(args ...interface{}) (interface{}, error) { panic("expected") }, nil),
+			Case: Case{ExpectedPanic: "expected", Comparer: func(actual, expected interface{}) (bool, string) {
+				panic("bad comparer")
+			}},
+			expResult: result{false, `FAIL: "comparer panic with ShouldPanic" panic expected does not match expected` + "\ncomparer panic: bad comparer"},
+		},
+		"project panic": {
+			trial: New(func(args ...interface{}) (interface{}, error) { return 1, nil }, nil).Project(func(v interface{}) interface{} {
+				return v.(string)
+			}),
+			Case:      Case{Expected: 1},
+			expResult: result{false, "FAIL: \"project panic\" \ncomparer panic: interface conversion: interface {} is int, not string"},
+		},
+		"project panic with ShouldPanic": {
+			trial: New(func(args ...interface{}) (interface{}, error) { return 1, nil }, nil).Project(func(v interface{}) interface{} {
+				panic("bad project")
+			}),
+			Case:      Case{ShouldPanic: true},
+			expResult: result{false, `FAIL: "project panic with ShouldPanic" did not panic`},
+		},
+		"diff formatter panic": {
+			trial: New(func(args ...interface{}) (interface{}, error) { return 1, nil }, nil).DiffFormatter(func(actual, expected interface{}) string {
+				panic("bad formatter")
+			}),
+			Case:      Case{Expected: 2},
+			expResult: result{false, "FAIL: \"diff formatter panic\" \ncomparer panic: bad formatter"},
+		},
+		"diff formatter": {
+			trial: New(func(args ...interface{}) (interface{}, error) { return 1, nil }, nil).DiffFormatter(func(actual, expected interface{}) string {
+				return fmt.Sprintf("got %v want %v", actual, expected)
+			}),
+			Case:      Case{Expected: 2},
+			expResult: result{false, "FAIL: \"diff formatter\" \ngot 1 want 2"},
+		},
+		"diff formatter keeps equality": {
+			trial: New(func(args ...interface{}) (interface{}, error) { return "ab", nil }, nil).Comparer(Contains).DiffFormatter(func(actual, expected interface{}) string {
+				return "formatted"
+			}),
+			Case:      Case{Expected: "a"},
+			expResult: result{true, `PASS: "diff formatter keeps equality"`},
+		},
+		"NoError with no error": {
+			trial:     New(func(args ...interface{}) (interface{}, error) { return 1, nil }, nil),
+			Case:      Case{Expected: 1, ExpectedErr: NoError},
+			expResult: result{true, `PASS: "NoError with no error"`},
+		},
+		"NoError with error": {
+			trial:     New(func(args ...interface{}) (interface{}, error) { return nil, io.EOF }, nil),
+			Case:      Case{ExpectedErr: NoError},
+			expResult: result{false, `FAIL: "NoError with error" unexpected error 'EOF'`},
+		},
+		"NoError compares result": {
+			trial:     New(func(args ...interface{}) (interface{}, error) { return 1, nil }, nil),
+			Case:      Case{Expected: 2, ExpectedErr: NoError},
+			expResult: result{false, "FAIL: \"NoError compares result\" \n"},
+		},
+		"expected panic value": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				panic("invalid state")
+			}, nil),
+			Case:      Case{ExpectedPanic: "invalid state"},
+			expResult: result{true, `PASS: "expected panic value"`},
+		},
+		"expected panic error": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				panic(errSentinel)
+			}, nil),
+			Case:      Case{ExpectedPanic: errSentinel},
+			expResult: result{true, `PASS: "expected panic error"`},
+		},
+		"panic value mismatch": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				panic("invalid state")
+			}, nil),
+			Case:      Case{ExpectedPanic: "closed", ShouldPanic: true},
+			expResult: result{false, "FAIL: \"panic value mismatch\" panic invalid state does not match expected\n"},
+		},
+		"expected panic implies ShouldPanic": {
+			trial:     New(divideFn, nil),
+			Case:      Case{Input: Args(1, 1), ExpectedPanic: "divide by zero"},
+			expResult: result{false, `FAIL: "expected panic implies ShouldPanic" did not panic`},
+		},
+		"skipped case": {
+			trial:     New(divideFn, nil),
+			Case:      Case{Input: Args(1, 0), Expected: 1, Skip: true},
+			expResult: result{true, `SKIP: "skipped case" skipped`},
+		},
+		"skip reason": {
+			trial:     New(divideFn, nil),
+			Case:      Case{Input: Args(1, 0), Expected: 1, SkipReason: "flaky on CI"},
+			expResult: result{true, `SKIP: "skip reason" flaky on CI`},
+		},
+		"deterministic result": {
+			trial:     New(divideFn, nil),
+			Case:      Case{Input: Args(6, 3), Expected: 2, Deterministic: 5},
+			expResult: result{true, `PASS: "deterministic result"`},
+		},
+		"non-deterministic result": {
+			trial: New(func() TestFunc {
+				var count int
+				return func(args ...interface{}) (interface{}, error) {
+					count++
+					return count / 3, nil
+				}
+			}(), nil),
+			Case:      Case{Expected: 0, Deterministic: 5},
+			expResult: result{false, `FAIL: "non-deterministic result" not deterministic, run 3 differs`},
+		},
+		"deterministic with comparer": {
+			trial: New(func() TestFunc {
+				var count float64
+				return func(args ...interface{}) (interface{}, error) {
+					count++
+					return 1 + count/1e9, nil
+				}
+			}(), nil).Comparer(ApproxEqual(1e-6)),
+			Case:      Case{Expected: 1.0, Deterministic: 3},
+			expResult: result{true, `PASS: "deterministic with comparer"`},
+		},
+		"deterministic run timed out": {
+			trial: New(func() TestFunc {
+				var count int
+				return func(args ...interface{}) (interface{}, error) {
+					if count++; count > 1 {
+						time.Sleep(time.Second)
+					}
+					return 1, nil
+				}
+			}(), nil),
+			Case:      Case{Expected: 1, Deterministic: 2, Timeout: 10 * time.Millisecond},
+			expResult: result{false, `FAIL: "deterministic run timed out" run 2 timed out after 10ms`},
+		},
+		"input mutated in place": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				sort.Ints(args[0].([]int))
+				return nil, nil
+			}, nil),
+			Case:      Case{Input: []int{3, 1, 2}, ExpectMutated: []int{1, 2, 3}},
+			expResult: result{true, `PASS: "input mutated in place"`},
+		},
+		"input mutated and result": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				s := args[0].([]int)
+				s[0] = 0
+				return len(s), nil
+			}, nil),
+			Case:      Case{Input: Args([]int{3, 1}), Expected: 2, ExpectMutated: Args([]int{0, 1})},
+			expResult: result{true, `PASS: "input mutated and result"`},
+		},
+		"input not mutated as expected": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				args[0].([]int)[0] = 9
+				return nil, nil
+			}, nil),
+			Case:      Case{Input: []int{3, 1, 2}, ExpectMutated: []int{1, 2, 3}},
+			expResult: result{false, `FAIL: "input not mutated as expected" input [3 1 2] mutated to [9 1 2]`},
+		},
+		"project result before compare": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return point{X: 1, Y: 2}, nil
+			}, nil).Project(func(i interface{}) interface{} {
+				return i.(point).Y
+			}),
+			Case:      Case{Expected: 2.0},
+			expResult: result{true, `PASS: "project result before compare"`},
+		},
+		"no assertion allowed by default": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, nil
+			}, nil),
+			Case:      Case{Input: 1},
+			expResult: result{true, `PASS: "no assertion allowed by default"`},
+		},
+		"no assertion with RequireAssertions": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, nil
+			}, nil).RequireAssertions(),
+			Case:      Case{Input: 1},
+			expResult: result{false, `FAIL: "no assertion with RequireAssertions" no assertion`},
+		},
+		"assertion with RequireAssertions": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return nil, errors.New("test error")
+			}, nil).RequireAssertions(),
+			Case:      Case{ShouldErr: true},
+			expResult: result{true, `PASS: "assertion with RequireAssertions"`},
+		},
+		"transform path before compare": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return point{X: 1.23456, Y: 2}, nil
+			}, nil).TransformPath("X", func(i interface{}) interface{} {
+				return math.Round(i.(float64)*100) / 100
+			}),
+			Case: Case{
+				Expected: point{X: 1.23, Y: 2},
+			},
+			expResult: result{true, `PASS: "transform path before compare"`},
+		},
+		"transform path only changes path": {
+			trial: New(func(args ...interface{}) (interface{}, error) {
+				return point{X: 1, Y: 2.001}, nil
+			}, nil).TransformPath("X", func(i interface{}) interface{} {
+				return math.Round(i.(float64))
+			}),
+			Case: Case{
+				Expected: point{X: 1, Y: 2},
+			},
+			expResult: result{false, `FAIL: "transform path only changes path"`},
+		},
 	}
 	for msg, test := range cases {
 		r := test.trial.testCase(msg, test.Case)
@@ -166,8 +575,270 @@ func TestTrial_TestCase(t *testing.T) {
 	}
 }
 
+// fieldErr is an error with data that's compared with ErrEqual
+type fieldErr struct {
+	Field string
+	Code  int
+}
+
+func (e *fieldErr) Error() string { return "invalid " + e.Field }
+
+// joinErr wraps multiple errors like errors.Join
+type joinErr []error
+
+func (e joinErr) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+func (e joinErr) Unwrap() []error { return e }
+
+// inRange is a Validator for numbers between min and max
+type inRange struct {
+	min, max float64
+}
+
+func (r inRange) Validate(actual interface{}) error {
+	f, _ := toFloat(actual)
+	if f < r.min || f > r.max {
+		return fmt.Errorf("%v is not between %v and %v", actual, r.min, r.max)
+	}
+	return nil
+}
+
+var errSentinel = errors.New("sentinel")
+
+type point struct {
+	X, Y float64
+}
+
 type testErr struct{}
 
 func (e testErr) Error() string {
 	return ""
 }
+
+func TestTrial_Only(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	// the non focused cases would fail if they were run
+	New(fn, Cases{
+		"focused":       {Input: 1, Expected: 1, Only: true},
+		"also focused":  {Input: 2, Expected: 2, Only: true},
+		"not focused":   {Input: 1, Expected: 2},
+		"still ignored": {Input: 1, ShouldErr: true},
+	}).Test(t)
+
+	tr := New(fn, Cases{"a": {}, "b": {Only: true}, "c": {}})
+	names, skipped := tr.focus([]string{"a", "b", "c"})
+	if eq, diff := Equal(names, []string{"b"}); !eq || skipped != 2 {
+		t.Errorf("FAIL: focus %d skipped %s", skipped, diff)
+	}
+	tr = New(fn, Cases{"a": {}, "b": {}})
+	if names, skipped := tr.focus([]string{"a", "b"}); len(names) != 2 || skipped != 0 {
+		t.Errorf("FAIL: all cases should run without Only %v", names)
+	}
+}
+
+func TestTrial_CaseOrder(t *testing.T) {
+	cases := Cases{}
+	for _, s := range []string{"d", "b", "a", "e", "c", "f", "h", "g"} {
+		cases[s] = Case{}
+	}
+	names := New(nil, cases).caseNames()
+	if eq, diff := Equal(names, []string{"a", "b", "c", "d", "e", "f", "g", "h"}); !eq {
+		t.Error("FAIL: cases not sorted", diff)
+	}
+	if names := New(nil, cases).Unordered().caseNames(); len(names) != len(cases) {
+		t.Errorf("FAIL: unordered cases %v", names)
+	}
+}
+
+func TestTrial_Run(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	results := New(fn, Cases{
+		"pass":    {Input: 1, Expected: 1},
+		"fail":    {Input: 1, Expected: 2},
+		"skipped": {Input: 1, Skip: true},
+	}).Run()
+
+	actual := make([]Result, len(results))
+	for i, r := range results {
+		actual[i] = Result{Name: r.Name, Success: r.Success}
+	}
+	expected := []Result{
+		{Name: "fail", Success: false},
+		{Name: "pass", Success: true},
+		{Name: "skipped", Success: true},
+	}
+	if eq, diff := Equal(actual, expected); !eq {
+		t.Error("FAIL: results", diff)
+	}
+	if len(results) == 3 && !strings.HasPrefix(results[0].Message, `FAIL: "fail"`) {
+		t.Errorf("FAIL: message %q", results[0].Message)
+	}
+}
+
+// recordTB records the messages of a test without failing it
+type recordTB struct {
+	testing.TB
+	fatal  []string
+	logs   []string
+	errors []string
+}
+
+func (r *recordTB) Helper() {}
+
+func (r *recordTB) Fatal(args ...interface{}) { r.fatal = append(r.fatal, fmt.Sprint(args...)) }
+
+func (r *recordTB) Log(args ...interface{}) { r.logs = append(r.logs, fmt.Sprint(args...)) }
+
+func (r *recordTB) Logf(format string, args ...interface{}) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+func (r *recordTB) Error(args ...interface{}) { r.errors = append(r.errors, fmt.Sprint(args...)) }
+
+func TestTrial_Ready(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) { return nil, nil }
+	cases := Cases{"nil": {}}
+
+	for name, tr := range map[string]*Trial{
+		"New":            New(nil, cases),
+		"NewWithContext": NewWithContext(nil, cases),
+		"NewWithCleanup": NewWithCleanup(nil, cases),
+	} {
+		r := &recordTB{}
+		tr.Test(r)
+		if len(r.fatal) != 1 || r.fatal[0] != errNilFunc {
+			t.Errorf("FAIL: %s nil TestFunc should fail fast %v", name, r.fatal)
+		}
+	}
+
+	r := &recordTB{}
+	New(fn, nil).Test(r)
+	if len(r.fatal) != 0 || len(r.logs) != 1 || r.logs[0] != "trial: no cases to run" {
+		t.Errorf("FAIL: empty trial should only log a warning %v %v", r.fatal, r.logs)
+	}
+
+	defer func() {
+		if rec := recover(); rec != errNilFunc {
+			t.Errorf("FAIL: Run with a nil TestFunc should panic %v", rec)
+		}
+	}()
+	New(nil, cases).Run()
+}
+
+func TestTrial_SubTestFallback(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return args[0], nil
+	}
+	r := &recordTB{}
+	New(fn, Cases{
+		"pass": {Input: 1, Expected: 1},
+		"fail": {Input: 1, Expected: 2},
+	}).Color(false).SubTest(r)
+	if len(r.logs) != 1 || r.logs[0] != `PASS: "pass"` {
+		t.Errorf("FAIL: logs %v", r.logs)
+	}
+	if len(r.errors) != 1 || !strings.HasPrefix(r.errors[0], `FAIL: "fail"`) {
+		t.Errorf("FAIL: errors %v", r.errors)
+	}
+}
+
+func TestTrial_IgnoreSync(t *testing.T) {
+	type cache struct {
+		mu     sync.RWMutex
+		once   *sync.Once
+		Values map[string]int
+	}
+	type service struct {
+		sync.Mutex
+		wg    sync.WaitGroup
+		Name  string
+		Cache *cache
+	}
+	fn := func(args ...interface{}) (interface{}, error) {
+		s := &service{Name: args[0].(string), Cache: &cache{once: &sync.Once{}, Values: map[string]int{"a": 1}}}
+		s.Lock()
+		s.wg.Add(1)
+		s.Cache.once.Do(func() {})
+		return s, nil
+	}
+	New(fn, Cases{
+		"locks ignored": {
+			Input:    "api",
+			Expected: &service{Name: "api", Cache: &cache{Values: map[string]int{"a": 1}}},
+		},
+	}).IgnoreSync().Test(t)
+
+	results := New(fn, Cases{
+		"fields compared": {
+			Input:    "api",
+			Expected: &service{Name: "web", Cache: &cache{Values: map[string]int{"a": 1}}},
+		},
+	}).IgnoreSync().Run()
+	if len(results) != 1 || results[0].Success {
+		t.Errorf("FAIL: exported fields should be compared %v", results)
+	}
+}
+
+func TestTrial_LazyInput(t *testing.T) {
+	var built int
+	buffer := func() interface{} {
+		built++
+		return &strings.Builder{}
+	}
+	write := func(args ...interface{}) (interface{}, error) {
+		b := args[0].(*strings.Builder)
+		b.WriteString("x")
+		return b.String(), nil
+	}
+	New(write, Cases{
+		"fresh input":      {Input: buffer, Expected: "x"},
+		"each run is new":  {Input: buffer, Expected: "x", Deterministic: 3},
+		"func of args":     {Input: func() []interface{} { return []interface{}{&strings.Builder{}} }, Expected: "x"},
+		"mutated is built": {Input: buffer, ExpectMutated: func() interface{} { b := &strings.Builder{}; b.WriteString("x"); return b }()},
+	}).Test(t)
+	if built != 5 {
+		t.Errorf("FAIL: input built %d times, expected 5", built)
+	}
+
+	// retries get a fresh input
+	attempts := 0
+	flaky := func(args ...interface{}) (interface{}, error) {
+		attempts++
+		b := args[0].(*strings.Builder)
+		if b.Len() != 0 {
+			return nil, errors.New("input reused")
+		}
+		b.WriteString("x")
+		if attempts < 2 {
+			return nil, errors.New("flaky")
+		}
+		return b.String(), nil
+	}
+	New(flaky, Cases{
+		"retry": {Input: buffer, Expected: "x", Retry: 2},
+	}).Test(t)
+}
+
+func TestErrAs_Target(t *testing.T) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		return ErrAs(args[0]) != nil, nil
+	}
+	var n int
+	New(fn, Cases{
+		"pointer to error":     {Input: &strconv.NumError{}, Expected: true},
+		"error value":          {Input: testErr{}, Expected: true},
+		"pointer to interface": {Input: (*fmt.Stringer)(nil), Expected: true},
+		"nil":                  {Input: nil, ExpectedPanic: "trial: ErrAs target must not be nil"},
+		"not an error":         {Input: &n, ExpectedPanic: "trial: ErrAs target *int must be an error or a pointer to an interface"},
+	}).Test(t)
+}
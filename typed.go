@@ -0,0 +1,99 @@
+package trial
+
+import (
+	"fmt"
+	"time"
+)
+
+// TypedCase is a Case with an Input of type I and an Expected result of type O
+// so both are checked at compile time (see NewTyped).
+type TypedCase[I, O any] struct {
+	Input    I
+	Expected O
+
+	// testing conditions
+	ShouldErr     bool        // is an error expected
+	ExpectedErr   error       // the error that was expected (nil is no error expected)
+	ShouldPanic   bool        // is a panic expected
+	ExpectedPanic interface{} // the value the case should panic with (implies ShouldPanic)
+
+	Timeout time.Duration // fail the case if the TestFunc doesn't return in time (0 waits forever)
+	Retry   int           // number of times a failing case is run again before it fails
+
+	Only       bool   // only run the cases with Only set
+	Skip       bool   // don't run the case
+	SkipReason string // why the case is skipped, also skips the case when set
+}
+
+// untyped converts the case to a Case used by the trial
+func (c TypedCase[I, O]) untyped() Case {
+	return Case{
+		Input:         typedInput[I]{c.Input},
+		Expected:      c.Expected,
+		ShouldErr:     c.ShouldErr,
+		ExpectedErr:   c.ExpectedErr,
+		ShouldPanic:   c.ShouldPanic,
+		ExpectedPanic: c.ExpectedPanic,
+		Timeout:       c.Timeout,
+		Retry:         c.Retry,
+		Only:          c.Only,
+		Skip:          c.Skip,
+		SkipReason:    c.SkipReason,
+	}
+}
+
+// typedInput keeps the Input of a TypedCase as a single arg,
+// even when I is a []interface{}
+type typedInput[I any] struct {
+	value I
+}
+
+func (in typedInput[I]) String() string {
+	return fmt.Sprint(in.value)
+}
+
+// TypedCases made during a typed trial
+type TypedCases[I, O any] map[string]TypedCase[I, O]
+
+// TypedTrial is a Trial of a func(I) (O, error) created with NewTyped.
+// The options of Trial can be used except those that inject args
+// (SharedFixture, WithRand and CountSteps), a func(I) has no way to receive them
+// so they are ignored
+type TypedTrial[I, O any] struct {
+	*Trial
+}
+
+// NewTyped trial for a func that takes an input of type I and returns an O.
+// The func and the Input and Expected values of the cases are checked at compile time.
+// The untyped New remains for dynamic use
+func NewTyped[I, O any](fn func(I) (O, error), cases map[string]TypedCase[I, O]) *TypedTrial[I, O] {
+	untyped := make(Cases, len(cases))
+	for name, c := range cases {
+		untyped[name] = c.untyped()
+	}
+	if fn == nil {
+		return &TypedTrial[I, O]{Trial: New(nil, untyped)}
+	}
+	t := New(func(args ...interface{}) (interface{}, error) {
+		in, ok := args[len(args)-1].(typedInput[I])
+		if !ok {
+			return nil, fmt.Errorf("input %T is not %T", args[len(args)-1], in.value)
+		}
+		return fn(in.value)
+	}, untyped)
+	return &TypedTrial[I, O]{Trial: t}
+}
+
+// Comparer overrides the default comparison of the results with a func of the result type.
+// Values that are not an O (eg a panic value) are compared with Equal
+func (t *TypedTrial[I, O]) Comparer(fn func(actual, expected O) (bool, string)) *TypedTrial[I, O] {
+	t.Trial.Comparer(func(actual, expected interface{}) (bool, string) {
+		a, ok1 := actual.(O)
+		e, ok2 := expected.(O)
+		if !ok1 || !ok2 {
+			return Equal(actual, expected)
+		}
+		return fn(a, e)
+	})
+	return t
+}
@@ -0,0 +1,85 @@
+package trial
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNewTyped(t *testing.T) {
+	NewTyped(strconv.Atoi, map[string]TypedCase[string, int]{
+		"parse": {
+			Input:    "12",
+			Expected: 12,
+		},
+		"error": {
+			Input:       "a",
+			ExpectedErr: errors.New("invalid syntax"),
+		},
+		"template error": {
+			Input:       "b",
+			ExpectedErr: ErrTemplate(`parsing "{{input}}"`),
+		},
+	}).Test(t)
+
+	type point struct{ X, Y int }
+	sum := func(p point) (int, error) { return p.X + p.Y, nil }
+	NewTyped(sum, map[string]TypedCase[point, int]{
+		"struct input": {Input: point{1, 2}, Expected: 3},
+	}).Test(t)
+
+	// a slice of interfaces is passed as a single Input
+	count := func(args []interface{}) (int, error) { return len(args), nil }
+	NewTyped(count, map[string]TypedCase[[]interface{}, int]{
+		"slice input": {Input: []interface{}{1, "a"}, Expected: 2},
+	}).Test(t)
+
+	results := NewTyped(sum, map[string]TypedCase[point, int]{
+		"wrong result": {Input: point{1, 2}, Expected: 4},
+	}).Run()
+	if len(results) != 1 || results[0].Success || !strings.HasPrefix(results[0].Message, `FAIL: "wrong result"`) {
+		t.Errorf("FAIL: typed comparison %v", results)
+	}
+}
+
+func TestTypedTrial_Comparer(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	load := func(id int) (user, error) {
+		if id < 0 {
+			return user{}, errors.New("invalid id")
+		}
+		return user{ID: id, Name: "User" + strconv.Itoa(id)}, nil
+	}
+	sameID := func(actual, expected user) (bool, string) {
+		if actual.ID != expected.ID {
+			return false, "id " + strconv.Itoa(actual.ID) + " != " + strconv.Itoa(expected.ID)
+		}
+		return true, ""
+	}
+	NewTyped(load, TypedCases[int, user]{
+		"only ids compared": {Input: 1, Expected: user{ID: 1}},
+		"error":             {Input: -1, ExpectedErr: errors.New("invalid id")},
+		"should error":      {Input: -2, ShouldErr: true},
+	}).Comparer(sameID).RequireAssertions().Test(t)
+
+	results := NewTyped(load, TypedCases[int, user]{
+		"different id": {Input: 1, Expected: user{ID: 2}},
+	}).Comparer(sameID).Run()
+	if len(results) != 1 || !strings.Contains(results[0].Message, "id 1 != 2") {
+		t.Errorf("FAIL: typed comparer %v", results)
+	}
+}
+
+func TestNewTyped_NilFunc(t *testing.T) {
+	defer func() {
+		if rec := recover(); rec != errNilFunc {
+			t.Errorf("FAIL: Run with a nil func should panic %v", rec)
+		}
+	}()
+	var fn func(string) (int, error)
+	NewTyped(fn, TypedCases[string, int]{"a": {Input: "1", Expected: 1}}).Run()
+}